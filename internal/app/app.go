@@ -10,6 +10,8 @@ import (
 
 	"github.com/kiryu-dev/mykinolist/internal/config"
 	"github.com/kiryu-dev/mykinolist/internal/controller"
+	"github.com/kiryu-dev/mykinolist/internal/infrastructure/email"
+	"github.com/kiryu-dev/mykinolist/internal/infrastructure/markdown"
 	"github.com/kiryu-dev/mykinolist/internal/infrastructure/repository"
 	"github.com/kiryu-dev/mykinolist/internal/infrastructure/webapi"
 	"github.com/kiryu-dev/mykinolist/internal/service"
@@ -25,22 +27,46 @@ func Run(config *config.Config) {
 			log.Fatal(err.Error())
 		}
 	}()
+	/* the notifications are only shown in the app, unless SMTP is configured */
+	var sender service.EmailSender
+	if config.SMTP.Host != "" {
+		sender = email.NewSMTPSender(config.SMTP)
+	}
 	var (
 		repo     = repository.New(db)
 		services = service.New(
-			repo.UserRepository,
-			repo.TokenRepository,
-			repo.ListRepository,
-			repo.MovieRepositroy,
+			&service.Repositories{
+				User:           repo.UserRepository,
+				Token:          repo.TokenRepository,
+				List:           repo.ListRepository,
+				Movie:          repo.MovieRepositroy,
+				Cache:          repo.MovieCacheRepository,
+				Viewing:        repo.ViewingRepository,
+				Review:         repo.ReviewRepository,
+				Tag:            repo.TagRepository,
+				Follow:         repo.FollowRepository,
+				Activity:       repo.ActivityRepository,
+				Recommendation: repo.RecommendationRepository,
+				Status:         repo.StatusRepository,
+				Notification:   repo.NotificationRepository,
+				Import:         repo.ImportRepository,
+			},
 			webapi.New(config.KinopoiskAPIKey),
+			markdown.New(),
+			sender,
 			config,
 		)
-		controller = controller.New(services.AuthService, services.ListService)
+		controller = controller.New(services)
 	)
 	server := http.Server{
 		Addr:    config.ListeningPort,
 		Handler: controller,
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, job := range services.Jobs {
+		go job.Run(ctx)
+	}
 	go func() {
 		if err := server.ListenAndServe(); err != nil {
 			log.Fatal(err.Error())
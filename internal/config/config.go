@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/subosito/gotenv"
@@ -16,12 +17,54 @@ type DBConfig struct {
 	SSLMode  string
 }
 
+type MoviesCacheConfig struct {
+	TTL              time.Duration
+	RefreshInterval  time.Duration
+	RefreshBatchSize int
+}
+
+type TrashConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+type RecommendationsConfig struct {
+	RefreshInterval time.Duration
+	MinCommonUsers  int
+	Neighbors       int
+}
+
+type NotificationsConfig struct {
+	CheckInterval    time.Duration
+	RefreshInterval  time.Duration
+	RefreshBatchSize int
+}
+
+type ImportsConfig struct {
+	ProcessInterval time.Duration
+	BatchSize       int
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
 type Config struct {
 	ListeningPort       string
 	JWTAccessSecretKey  string
 	JWTRefreshSecretKey string
 	KinopoiskAPIKey     string
 	DB                  *DBConfig
+	MoviesCache         *MoviesCacheConfig
+	Trash               *TrashConfig
+	Recommendations     *RecommendationsConfig
+	Notifications       *NotificationsConfig
+	Imports             *ImportsConfig
+	SMTP                *SMTPConfig
 }
 
 func LoadConfig(configPath string) (*Config, error) {
@@ -45,6 +88,36 @@ func LoadConfig(configPath string) (*Config, error) {
 			DBName:   viper.GetString("db.dbname"),
 			SSLMode:  viper.GetString("db.sslmode"),
 		},
+		MoviesCache: &MoviesCacheConfig{
+			TTL:              viper.GetDuration("movies_cache.ttl"),
+			RefreshInterval:  viper.GetDuration("movies_cache.refresh_interval"),
+			RefreshBatchSize: viper.GetInt("movies_cache.refresh_batch_size"),
+		},
+		Trash: &TrashConfig{
+			Retention:     viper.GetDuration("trash.retention"),
+			PurgeInterval: viper.GetDuration("trash.purge_interval"),
+		},
+		Recommendations: &RecommendationsConfig{
+			RefreshInterval: viper.GetDuration("recommendations.refresh_interval"),
+			MinCommonUsers:  viper.GetInt("recommendations.min_common_users"),
+			Neighbors:       viper.GetInt("recommendations.neighbors"),
+		},
+		Notifications: &NotificationsConfig{
+			CheckInterval:    viper.GetDuration("notifications.check_interval"),
+			RefreshInterval:  viper.GetDuration("notifications.refresh_interval"),
+			RefreshBatchSize: viper.GetInt("notifications.refresh_batch_size"),
+		},
+		Imports: &ImportsConfig{
+			ProcessInterval: viper.GetDuration("imports.process_interval"),
+			BatchSize:       viper.GetInt("imports.batch_size"),
+		},
+		SMTP: &SMTPConfig{
+			Host:     viper.GetString("smtp.host"),
+			Port:     viper.GetString("smtp.port"),
+			Username: viper.GetString("smtp.username"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     viper.GetString("smtp.from"),
+		},
 	}
 	return config, nil
 }
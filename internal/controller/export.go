@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+const (
+	exportCSV        = "csv"
+	exportJSON       = "json"
+	exportLetterboxd = "letterboxd"
+)
+
+// ExportMovies godoc
+// @Summary      Export list
+// @Security	 AccessToken
+// @Description  Download all the movies of the list with their statuses, scores, favorite flags, timestamps and Kinopoisk and IMDb IDs as CSV or JSON. The letterboxd format is a CSV file of completed movies for the Letterboxd importer
+// @Tags         list
+// @Produce      json
+// @Produce      text/csv
+// @Param 		 format query string false "Format of the file" Enums(csv, json, letterboxd) default(csv)
+// @Success      200      {array}   model.ListUnit
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/export [get]
+func (h *listHandler) exportMovies(w http.ResponseWriter, r *http.Request) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exportCSV
+	}
+	filter := &model.ListFilter{ListInfo: *list}
+	var exp exporter
+	switch format {
+	case exportCSV:
+		exp = &csvExporter{writer: csv.NewWriter(w)}
+	case exportJSON:
+		exp = &jsonExporter{writer: w}
+	case exportLetterboxd:
+		/* the movies that haven't been watched yet make a watchlist, which is imported another way */
+		filter.Status = "completed"
+		exp = &csvExporter{writer: csv.NewWriter(w), letterboxd: true}
+	default:
+		writeErrorJSON(w, http.StatusBadRequest, "format must be one of csv, json, letterboxd")
+		return
+	}
+	/* the response starts with the first page, so that errors before it still get their status */
+	started := false
+	err = h.service.ExportMovies(filter, func(movies []*model.ListUnit) error {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", exp.contentType())
+			w.Header().Set("Content-Disposition",
+				fmt.Sprintf("attachment; filename=\"mykinolist-%s.%s\"", format, exp.extension()))
+			w.WriteHeader(http.StatusOK)
+		}
+		return exp.write(movies)
+	})
+	if err == nil {
+		err = exp.close()
+	}
+	if err != nil && !started {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("export of list %d has been interrupted: %s", list.ListID, err)
+	}
+}
+
+type exporter interface {
+	contentType() string
+	extension() string
+	write([]*model.ListUnit) error
+	close() error
+}
+
+type csvExporter struct {
+	writer     *csv.Writer
+	letterboxd bool
+	hasHeader  bool
+}
+
+/*
+the score is on the user's scale; the columns are the ones of the Letterboxd importer,
+where Rating10 is the score on the 1-10 scale
+*/
+var (
+	csvHeader        = []string{"kinopoisk_id", "imdb_id", "name", "en_name", "year", "status", "score", "is_favorite", "added_at", "updated_at", "completed_at", "tags"}
+	letterboxdHeader = []string{"imdbID", "Title", "Year", "Rating10", "WatchedDate", "Tags"}
+)
+
+func (e *csvExporter) contentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (e *csvExporter) extension() string {
+	return "csv"
+}
+
+func (e *csvExporter) write(movies []*model.ListUnit) error {
+	if !e.hasHeader {
+		e.hasHeader = true
+		header := csvHeader
+		if e.letterboxd {
+			header = letterboxdHeader
+		}
+		if err := e.writer.Write(header); err != nil {
+			return err
+		}
+	}
+	for _, movie := range movies {
+		record := e.record(movie)
+		if err := e.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvExporter) record(movie *model.ListUnit) []string {
+	var score, year, completedAt string
+	if movie.Score != 0 {
+		score = model.FormatScore(movie.Score)
+	}
+	if movie.Year != 0 {
+		year = strconv.Itoa(movie.Year)
+	}
+	if e.letterboxd {
+		/* Letterboxd doesn't know the user's scale */
+		if movie.RawScore != 0 {
+			score = model.FormatScore(model.ScaleScore(movie.RawScore, model.ScaleTen))
+		}
+		if movie.CompletedAt != nil {
+			completedAt = movie.CompletedAt.Format(time.DateOnly)
+		}
+		title := movie.EnName
+		if title == "" {
+			title = movie.Name
+		}
+		return []string{movie.IMDbID, title, year, score, completedAt, strings.Join(movie.Tags, ", ")}
+	}
+	if movie.CompletedAt != nil {
+		completedAt = movie.CompletedAt.Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatInt(movie.ID, 10), movie.IMDbID, movie.Name, movie.EnName, year,
+		movie.Status, score, strconv.FormatBool(movie.IsFavorite),
+		movie.AddedAt.Format(time.RFC3339), movie.UpdatedAt.Format(time.RFC3339), completedAt,
+		strings.Join(movie.Tags, ", "),
+	}
+}
+
+func (e *csvExporter) close() error {
+	/* an empty list still has the header */
+	return e.write(nil)
+}
+
+/* the array is written element by element */
+type jsonExporter struct {
+	writer io.Writer
+	count  int
+}
+
+func (e *jsonExporter) contentType() string {
+	return "application/json"
+}
+
+func (e *jsonExporter) extension() string {
+	return "json"
+}
+
+func (e *jsonExporter) write(movies []*model.ListUnit) error {
+	for _, movie := range movies {
+		prefix := ",\n"
+		if e.count == 0 {
+			prefix = "[\n"
+		}
+		data, err := json.Marshal(movie)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(e.writer, prefix); err != nil {
+			return err
+		}
+		if _, err := e.writer.Write(data); err != nil {
+			return err
+		}
+		e.count++
+	}
+	return nil
+}
+
+func (e *jsonExporter) close() error {
+	if e.count == 0 {
+		_, err := io.WriteString(e.writer, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(e.writer, "\n]\n")
+	return err
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_exportMovies(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, filter *model.ListFilter)
+	type testCase struct {
+		name                 string
+		target               string
+		inputFilter          model.ListFilter
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}
+	var (
+		addedAt     = time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+		completedAt = time.Date(2023, 7, 2, 21, 30, 0, 0, time.UTC)
+		pages       = [][]*model.ListUnit{
+			{{
+				Movie:       model.Movie{ID: 301, IMDbID: "tt0133093", Name: "Матрица", EnName: "The Matrix", Year: 1999},
+				Status:      "completed",
+				Score:       9,
+				RawScore:    90,
+				IsFavorite:  true,
+				AddedAt:     addedAt,
+				UpdatedAt:   completedAt,
+				CompletedAt: &completedAt,
+				Tags:        []string{"cyberpunk", "rewatch"},
+			}},
+			{{
+				Movie:     model.Movie{ID: 409424, Name: "Чернобыль", Year: 2019},
+				Status:    "plan to watch",
+				AddedAt:   addedAt,
+				UpdatedAt: addedAt,
+			}},
+		}
+		exportPages = func(filter *model.ListFilter, write func([]*model.ListUnit) error) error {
+			for _, page := range pages {
+				if err := write(page); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	)
+	testCases := []testCase{
+		{
+			name:        "CSV",
+			target:      "/list/export",
+			inputFilter: model.ListFilter{ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, filter *model.ListFilter) {
+				s.EXPECT().ExportMovies(filter, gomock.Any()).DoAndReturn(exportPages)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedResponseBody: "kinopoisk_id,imdb_id,name,en_name,year,status,score,is_favorite,added_at,updated_at,completed_at,tags\n" +
+				"301,tt0133093,Матрица,The Matrix,1999,completed,9,true,2023-07-01T12:00:00Z,2023-07-02T21:30:00Z,2023-07-02T21:30:00Z,\"cyberpunk, rewatch\"\n" +
+				"409424,,Чернобыль,,2019,plan to watch,,false,2023-07-01T12:00:00Z,2023-07-01T12:00:00Z,,\n",
+		},
+		{
+			name:        "JSON",
+			target:      "/list/export?format=json",
+			inputFilter: model.ListFilter{ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, filter *model.ListFilter) {
+				s.EXPECT().ExportMovies(filter, gomock.Any()).DoAndReturn(exportPages)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/json",
+			expectedResponseBody: "[\n" +
+				"{\"id\":301,\"name\":\"Матрица\",\"en_name\":\"The Matrix\",\"year\":1999,\"imdb_id\":\"tt0133093\",\"status\":\"completed\",\"score\":9,\"is_favorite\":true,\"added_at\":\"2023-07-01T12:00:00Z\",\"updated_at\":\"2023-07-02T21:30:00Z\",\"completed_at\":\"2023-07-02T21:30:00Z\",\"tags\":[\"cyberpunk\",\"rewatch\"],\"version\":0},\n" +
+				"{\"id\":409424,\"name\":\"Чернобыль\",\"year\":2019,\"status\":\"plan to watch\",\"score\":0,\"is_favorite\":false,\"added_at\":\"2023-07-01T12:00:00Z\",\"updated_at\":\"2023-07-01T12:00:00Z\",\"version\":0}\n" +
+				"]\n",
+		},
+		{
+			name:        "Letterboxd",
+			target:      "/list/export?format=letterboxd",
+			inputFilter: model.ListFilter{ListInfo: model.ListInfo{OwnerID: 42}, Status: "completed"},
+			mockBehavior: func(s *mock_service.MockListService, filter *model.ListFilter) {
+				s.EXPECT().ExportMovies(filter, gomock.Any()).DoAndReturn(
+					func(filter *model.ListFilter, write func([]*model.ListUnit) error) error {
+						return write(pages[0])
+					})
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedResponseBody: "imdbID,Title,Year,Rating10,WatchedDate,Tags\n" +
+				"tt0133093,The Matrix,1999,9,2023-07-02,\"cyberpunk, rewatch\"\n",
+		},
+		{
+			name:        "Nonexistent list",
+			target:      "/list/export?format=json",
+			inputFilter: model.ListFilter{ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, filter *model.ListFilter) {
+				s.EXPECT().ExportMovies(filter, gomock.Any()).Return(&model.NotFoundError{Message: "list 7 doesn't exist"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedContentType:  "application/json",
+			expectedResponseBody: "{\"error\":\"list 7 doesn't exist\"}\n",
+		},
+		{
+			name:                 "Invalid format",
+			target:               "/list/export?format=xml",
+			mockBehavior:         func(s *mock_service.MockListService, filter *model.ListFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json",
+			expectedResponseBody: "{\"error\":\"format must be one of csv, json, letterboxd\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputFilter)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list/export", handler.exportMovies).Methods(http.MethodGet)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
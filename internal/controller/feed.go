@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/kiryu-dev/mykinolist/internal/service"
+)
+
+type feedHandler struct {
+	service service.FeedService
+}
+
+// Follow godoc
+// @Summary      Follow user
+// @Security	 AccessToken
+// @Description  Follow the user to see events of their public lists in the feed. Users with private profiles cannot be followed
+// @Tags         feed
+// @Produce      json
+// @Param 		 username path string true "Username"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /following/{username} [put]
+func (h *feedHandler) follow(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	if err := h.service.Follow(userID, mux.Vars(r)["username"]); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("user has been followed"))
+}
+
+// Unfollow godoc
+// @Summary      Unfollow user
+// @Security	 AccessToken
+// @Description  Stop following the user
+// @Tags         feed
+// @Produce      json
+// @Param 		 username path string true "Username"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /following/{username} [delete]
+func (h *feedHandler) unfollow(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	if err := h.service.Unfollow(userID, mux.Vars(r)["username"]); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("user has been unfollowed"))
+}
+
+// GetFollowing godoc
+// @Summary      Get followed users
+// @Security	 AccessToken
+// @Description  Get the users that are followed by the user
+// @Tags         feed
+// @Produce      json
+// @Success      200      {array}   model.FollowedUser
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /following [get]
+func (h *feedHandler) getFollowing(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	users, err := h.service.GetFollowing(userID)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, users)
+}
+
+// GetFollowers godoc
+// @Summary      Get followers
+// @Security	 AccessToken
+// @Description  Get the users following the user
+// @Tags         feed
+// @Produce      json
+// @Success      200      {array}   model.FollowedUser
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /followers [get]
+func (h *feedHandler) getFollowers(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	users, err := h.service.GetFollowers(userID)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, users)
+}
+
+// GetFeed godoc
+// @Summary      Get feed
+// @Security	 AccessToken
+// @Description  Get events of public lists of the followed users page by page, newest first: added, completed, scored, favorited and reviewed movies. Pass next_cursor of the response to get the next page
+// @Tags         feed
+// @Produce      json
+// @Param 		 cursor query string false "Cursor of the page"
+// @Param 		 limit  query int    false "Page size" default(100) maximum(500)
+// @Success      200      {object}  model.Feed
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /feed [get]
+func (h *feedHandler) getFeed(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	query := &model.FeedQuery{
+		UserID: r.Context().Value(userIDKey{}).(int64),
+		Cursor: values.Get("cursor"),
+	}
+	if values.Has("limit") {
+		limit, err := strconv.Atoi(values.Get("limit"))
+		if err != nil {
+			writeErrorJSON(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		query.Limit = limit
+	}
+	feed, err := h.service.GetFeed(query)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, feed)
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_getFeed(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockFeedService, query *model.FeedQuery)
+	type testCase struct {
+		name                 string
+		target               string
+		inputQuery           model.FeedQuery
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	createdAt := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []testCase{
+		{
+			name:       "OK",
+			target:     "/feed?limit=1",
+			inputQuery: model.FeedQuery{UserID: 42, Limit: 1},
+			mockBehavior: func(s *mock_service.MockFeedService, query *model.FeedQuery) {
+				s.EXPECT().GetFeed(query).Return(&model.Feed{
+					Activities: []*model.Activity{{
+						ID:        3,
+						Username:  "kiryu",
+						Kind:      model.ActivityScored,
+						Movie:     model.Movie{ID: 301, Name: "Матрица"},
+						Score:     9,
+						ListSlug:  "7-horror-marathon",
+						CreatedAt: createdAt,
+					}},
+					NextCursor: "WyIyMDIzLTA3LTAxIDEyOjAwOjAwIiwiMyJd",
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"activities\":[{\"id\":3,\"username\":\"kiryu\",\"kind\":\"scored\",\"movie\":{\"id\":301,\"name\":\"Матрица\"},\"score\":9,\"list_slug\":\"7-horror-marathon\",\"created_at\":\"2023-07-01T12:00:00Z\"}],\"next_cursor\":\"WyIyMDIzLTA3LTAxIDEyOjAwOjAwIiwiMyJd\"}\n",
+		},
+		{
+			name:       "Invalid cursor",
+			target:     "/feed?cursor=abc",
+			inputQuery: model.FeedQuery{UserID: 42, Cursor: "abc"},
+			mockBehavior: func(s *mock_service.MockFeedService, query *model.FeedQuery) {
+				s.EXPECT().GetFeed(query).Return(nil, fmt.Errorf("invalid cursor"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid cursor\"}\n",
+		},
+		{
+			name:                 "Invalid limit",
+			target:               "/feed?limit=many",
+			mockBehavior:         func(s *mock_service.MockFeedService, query *model.FeedQuery) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid limit\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			feed := mock_service.NewMockFeedService(c)
+			tc.mockBehavior(feed, &tc.inputQuery)
+			var (
+				handler = &feedHandler{service: feed}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/feed", handler.getFeed).Methods(http.MethodGet)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -3,7 +3,9 @@ package controller
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/gorilla/mux"
@@ -18,68 +20,231 @@ type listHandler struct {
 // AddMovie godoc
 // @Summary      Add movie to list
 // @Security	 AccessToken
-// @Description  Use a third-party API to search for movie information by title and, if successful, add the movie to the list. You can add the movie to your favorites, rate it, and specify movie status (watching, plan to watch, etc.)
+// @Description  Add the movie with the specified Kinopoisk ID (see /movies/search) to the list. If there's no ID, use a third-party API to search for movie information by title and, if successful, add the first found movie to the list. You can add the movie to your favorites, rate it, and specify movie status (watching, plan to watch, etc. or one of your own statuses, see /statuses). A deleted movie can't be added again while it's in the trash, restore it instead (see /list/trash/{id}/restore)
 // @Tags         list
 // @Accept       json
 // @Produce      json
 // @Param 		 input body model.ListUnit true "movie info"
 // @Success      200      {string}  string
 // @Failure      400,404  {object}  errorResponse
+// @Failure      409      {object}  errorResponse
 // @Failure      500      {object}  errorResponse
 // @Failure      default  {object}  errorResponse
 // @Router       /list [post]
 func (h *listHandler) addMovie(w http.ResponseWriter, r *http.Request) {
-	id := r.Context().Value(userIDKey{}).(int64)
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	req := new(model.ListUnit)
 	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
 		writeErrorJSON(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	r.Body.Close()
-	req.OwnerID = id
+	req.ListInfo = *list
 	if err := h.service.AddMovie(req); err != nil {
-		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
 		return
 	}
 	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(fmt.Sprintf("Movie %s has successfully added to user's %d list", req.Name, id)))
+	w.Write([]byte(fmt.Sprintf("Movie %s has successfully added to user's %d list", req.Name, req.OwnerID)))
+}
+
+// ImportMovies godoc
+// @Summary      Import movies to list
+// @Security	 AccessToken
+// @Description  Import the CSV file exported from Letterboxd (watched.csv, ratings.csv or watchlist.csv) or IMDb (ratings.csv). The rows are matched and added to the list in the background, see GET /list/import/{id} for the progress. Rows with IMDb ID are matched exactly, the others (and the ones whose ID isn't found) are searched by title and year. Scores are converted to the score scale of the user, the movies are completed or planned to watch in case of a watchlist; the movies that are already in the list get the status and the score of the row
+// @Tags         list
+// @Accept       mpfd
+// @Produce      json
+// @Param 		 format query    string true "Format of the file" Enums(letterboxd_watched, letterboxd_ratings, letterboxd_watchlist, imdb_ratings)
+// @Param 		 file   formData file   true "CSV file"
+// @Success      202      {object}  model.ImportProgress
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/import [post]
+func (h *listHandler) importMovies(w http.ResponseWriter, r *http.Request) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+	format := r.URL.Query().Get("format")
+	rows, err := model.ReadImportRows(format, file)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	progress, err := h.service.ImportMovies(&model.Import{ListInfo: *list, Format: format, Rows: rows})
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusAccepted, progress)
+}
+
+// GetImport godoc
+// @Summary      Get import progress
+// @Security	 AccessToken
+// @Description  Get the progress of the import: how many rows have been processed, and the report of them, which tells which rows have been matched, which are ambiguous (use the ID of the right candidate to add the movie to the list) and which have failed. The import is finished when it has finished_at
+// @Tags         list
+// @Produce      json
+// @Param 		 id path int true "Import ID"
+// @Success      200      {object}  model.ImportProgress
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/import/{id} [get]
+func (h *listHandler) getImport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	progress, err := h.service.GetImport(userID, id)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, progress)
+}
+
+// BatchMovies godoc
+// @Summary      Apply batch of operations to list
+// @Security	 AccessToken
+// @Description  Add (by Kinopoisk ID), update and delete up to 100 movies of the list in one transaction, e.g. to sync the changes made offline. Every operation has its own result with the HTTP code of the error; the failed operations aren't applied, the others are. If the batch is atomic, a failed operation rolls back the whole batch
+// @Tags         list
+// @Accept       json
+// @Produce      json
+// @Param 		 input body model.Batch true "operations"
+// @Success      200      {object}  model.BatchReport
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/batch [post]
+func (h *listHandler) batchMovies(w http.ResponseWriter, r *http.Request) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.Batch)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	r.Body.Close()
+	req.ListInfo = *list
+	report, err := h.service.BatchMovies(req)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	for _, result := range report.Results {
+		if result.Err != nil {
+			result.Code = errorStatusCode(result.Err, http.StatusBadRequest)
+			result.Error = result.Err.Error()
+		}
+	}
+	writeJSONResponse(w, http.StatusOK, report)
 }
 
 // GetMovies godoc
 // @Summary      Get movies
 // @Security	 AccessToken
-// @Description  Get all movies from list
+// @Description  Get movies from list page by page. Movies can be filtered and sorted; by default favorites go first, then movies with higher score, then in the manual order (sort=rank, see /list/order). Pass next_cursor of the response to get the next page
 // @Tags         list
 // @Produce      json
-// @Success      200      {array}   model.ListUnit
+// @Param 		 status     query string false "Movie status"
+// @Param 		 favorite   query bool   false "Only favorites (true) or only not favorites (false)"
+// @Param 		 min_score  query number false "Min score, up to the max of the user's score scale"
+// @Param 		 max_score  query number false "Max score, up to the max of the user's score scale"
+// @Param 		 genre      query string false "Genre"
+// @Param 		 tag        query []string false "Tag, movies having all of the tags are returned" collectionFormat(multi)
+// @Param 		 year       query int    false "Release year"
+// @Param 		 sort       query string false "Sort by" Enums(score, added, name, year, rank)
+// @Param 		 order      query string false "Sort order" Enums(asc, desc)
+// @Param 		 cursor     query string false "Cursor of the page"
+// @Param 		 limit      query int    false "Page size" default(100) maximum(500)
+// @Success      200      {object}  model.ListPage
 // @Failure      400,404  {object}  errorResponse
 // @Failure      500      {object}  errorResponse
 // @Failure      default  {object}  errorResponse
 // @Router       /list [get]
 func (h *listHandler) getMovies(w http.ResponseWriter, r *http.Request) {
-	id := r.Context().Value(userIDKey{}).(int64)
-	movies, err := h.service.GetMovies(id)
+	filter, err := listFilterFromRequest(r)
 	if err != nil {
 		writeErrorJSON(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	writeJSONResponse(w, http.StatusOK, movies)
+	page, err := h.service.GetMovies(filter)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, page)
+}
+
+// GetMovie godoc
+// @Summary      Get movie
+// @Security	 AccessToken
+// @Description  Get the movie from the list. ETag header holds the version of the movie to be passed in If-Match header of PATCH request
+// @Tags         list
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Success      200      {object}  model.ListUnit
+// @Header       200      {string}  ETag  "Version of the movie"
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id} [get]
+func (h *listHandler) getMovie(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.GetMovie(movie); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	w.Header().Set("ETag", entityTag(movie.Version))
+	writeJSONResponse(w, http.StatusOK, movie)
 }
 
 // UpdateMovie godoc
 // @Summary      Update movie info
 // @Security	 AccessToken
-// @Description  Update part of the information about the added movie. For example, you can add the movie to your favorites or change the rating of the movie.
+// @Description  Update part of the information about the added movie. For example, you can add the movie to your favorites or change the rating of the movie (on the score scale of the user, see PATCH /user/{id}). Pass ETag of the movie in If-Match header not to overwrite changes made by someone else: if the movie has been changed since then, the update fails with 412
 // @Tags         list
+// @Accept       json
 // @Produce      json
-// @Param 		 id path int true "Movie ID"
+// @Param 		 id       path   int                 true  "Movie ID"
+// @Param 		 If-Match header string              false "ETag of the movie"
+// @Param 		 input    body   model.ListUnitPatch true  "movie info"
 // @Success      200      {string}  string
+// @Header       200      {string}  ETag  "New version of the movie"
 // @Failure      400,404  {object}  errorResponse
+// @Failure      412      {object}  errorResponse
 // @Failure      500      {object}  errorResponse
 // @Failure      default  {object}  errorResponse
 // @Router       /list/{id} [patch]
 func (h *listHandler) updateMovie(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value(userIDKey{}).(int64)
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	idStr := mux.Vars(r)["id"]
 	movieID, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -92,20 +257,159 @@ func (h *listHandler) updateMovie(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer r.Body.Close()
-	req.OwnerID = &userID
+	req.OwnerID = &list.OwnerID
+	if list.ListID != 0 {
+		req.ListID = &list.ListID
+	}
 	req.MovieID = &movieID
-	if err := h.service.UpdateMovie(req); err != nil {
+	if req.Version, err = versionFromRequest(r); err != nil {
 		writeErrorJSON(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if err := h.service.UpdateMovie(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.Header().Set("ETag", entityTag(*req.Version))
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("movie data has been updated"))
 }
 
+// GetHistory godoc
+// @Summary      Get movie history
+// @Security	 AccessToken
+// @Description  Get the history of status and score changes of the movie, the oldest changes go first. The first entry is adding the movie to the list
+// @Tags         list
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Success      200      {array}   model.HistoryEntry
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/history [get]
+func (h *listHandler) getHistory(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	history, err := h.service.GetHistory(movie)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, history)
+}
+
+// IncrementProgress godoc
+// @Summary      Increment series progress
+// @Security	 AccessToken
+// @Description  Mark the next episodes of the series as watched (one by default), moving on to the next season when the current one is over. The series status becomes "watching", or "completed" after the last episode
+// @Tags         list
+// @Accept       json
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Param 		 input body model.ProgressIncrement false "count of watched episodes"
+// @Success      200      {object}  model.ListUnit
+// @Failure      400,404  {object}  errorResponse
+// @Failure      409      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/progress [post]
+func (h *listHandler) incrementProgress(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.ProgressIncrement)
+	/* the body may be omitted to increment the progress by one episode */
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	if err := h.service.IncrementProgress(movie, req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, movie)
+}
+
+// ReorderMovies godoc
+// @Summary      Reorder movies
+// @Security	 AccessToken
+// @Description  Put the movies in the given manual order (see sort=rank). The movies that are already in order keep their places, the others are moved next to them, so the movies that aren't specified keep their places too
+// @Tags         list
+// @Accept       json
+// @Produce      json
+// @Param 		 input body model.RankOrder true "movie IDs in the new order"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/order [put]
+func (h *listHandler) reorderMovies(w http.ResponseWriter, r *http.Request) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.RankOrder)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.ListInfo = *list
+	if err := h.service.ReorderMovies(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("movies have been reordered"))
+}
+
+// MoveMovie godoc
+// @Summary      Move movie
+// @Security	 AccessToken
+// @Description  Move the movie right before or right after another movie of the list in the manual order (see sort=rank)
+// @Tags         list
+// @Accept       json
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Param 		 input body model.RankMove true "the movie to move the movie next to"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/move [post]
+func (h *listHandler) moveMovie(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.RankMove)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.MovieID = movie.ID
+	req.ListInfo = movie.ListInfo
+	if err := h.service.MoveMovie(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("movie has been moved"))
+}
+
 // DeleteMovie godoc
 // @Summary      Delete movie
 // @Security	 AccessToken
-// @Description  Delete movie from list
+// @Description  Move the movie from the list to the trash. It can be restored until it's purged after the retention period
 // @Tags         list
 // @Produce      json
 // @Param 		 id path int true "Movie ID"
@@ -115,7 +419,11 @@ func (h *listHandler) updateMovie(w http.ResponseWriter, r *http.Request) {
 // @Failure      default  {object}  errorResponse
 // @Router       /list/{id} [delete]
 func (h *listHandler) deleteMovie(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value(userIDKey{}).(int64)
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	idStr := mux.Vars(r)["id"]
 	movieID, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -124,11 +432,218 @@ func (h *listHandler) deleteMovie(w http.ResponseWriter, r *http.Request) {
 	}
 	req := &model.ListUnit{
 		Movie:    model.Movie{ID: movieID},
-		ListInfo: model.ListInfo{OwnerID: userID},
+		ListInfo: *list,
 	}
 	if err := h.service.DeleteMovie(req); err != nil {
-		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
 		return
 	}
 	writeJSONResponse(w, http.StatusOK, req)
 }
+
+// GetTrash godoc
+// @Summary      Get trash
+// @Security	 AccessToken
+// @Description  Get deleted movies of the list, the last deleted go first. They are purged after the retention period
+// @Tags         list
+// @Produce      json
+// @Success      200      {array}   model.ListUnit
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/trash [get]
+func (h *listHandler) getTrash(w http.ResponseWriter, r *http.Request) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	movies, err := h.service.GetTrash(list)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, movies)
+}
+
+// RestoreMovie godoc
+// @Summary      Restore movie
+// @Security	 AccessToken
+// @Description  Bring the deleted movie back to the list with its status, score, etc.
+// @Tags         list
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Success      200      {object}  model.ListUnit
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/trash/{id}/restore [post]
+func (h *listHandler) restoreMovie(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.RestoreMovie(movie); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, movie)
+}
+
+// GetCollections godoc
+// @Summary      Get collections
+// @Security	 AccessToken
+// @Description  Group the movies of the list by franchise, using sequels and prequels from Kinopoisk. Every collection has all known parts in order of release, including the ones that aren't in the list (they have no status) and the trashed ones (they're marked as trashed), and tells how many parts have been watched (completed)
+// @Tags         list
+// @Produce      json
+// @Success      200      {array}   model.Collection
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/collections [get]
+func (h *listHandler) getCollections(w http.ResponseWriter, r *http.Request) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	collections, err := h.service.GetCollections(list)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, collections)
+}
+
+// PlanCollection godoc
+// @Summary      Plan remaining parts of collection
+// @Security	 AccessToken
+// @Description  Add the parts of the collection that aren't in the list yet as planned to watch. The trashed parts are skipped, restore them from the trash instead. The collection is found by any of its parts
+// @Tags         list
+// @Produce      json
+// @Param 		 id path int true "Movie ID of any part of the collection"
+// @Success      200      {array}   model.ListUnit
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/collections/{id}/plan [post]
+func (h *listHandler) planCollection(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	added, err := h.service.PlanCollection(&model.CollectionPlan{MovieID: movie.ID, ListInfo: movie.ListInfo})
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, added)
+}
+
+/*
+movie routes are served both as /list (the default list)
+and /lists/{listID}/movies, so the list ID is optional
+*/
+func listInfoFromRequest(r *http.Request) (*model.ListInfo, error) {
+	list := &model.ListInfo{OwnerID: r.Context().Value(userIDKey{}).(int64)}
+	idStr, ok := mux.Vars(r)["listID"]
+	if !ok {
+		return list, nil
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	list.ListID = id
+	return list, nil
+}
+
+func listFilterFromRequest(r *http.Request) (*model.ListFilter, error) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	return listFilterFromQuery(r.URL.Query(), list)
+}
+
+func listFilterFromQuery(values url.Values, list *model.ListInfo) (*model.ListFilter, error) {
+	filter := &model.ListFilter{
+		ListInfo: *list,
+		Status:   values.Get("status"),
+		Genre:    values.Get("genre"),
+		Sort:     values.Get("sort"),
+		Order:    values.Get("order"),
+		Cursor:   values.Get("cursor"),
+		Tags:     values["tag"],
+	}
+	if values.Has("favorite") {
+		isFavorite, err := strconv.ParseBool(values.Get("favorite"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid favorite")
+		}
+		filter.IsFavorite = &isFavorite
+	}
+	for param, dest := range map[string]**float64{
+		"min_score": &filter.MinScore,
+		"max_score": &filter.MaxScore,
+	} {
+		if !values.Has(param) {
+			continue
+		}
+		score, err := strconv.ParseFloat(values.Get(param), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s", param)
+		}
+		*dest = &score
+	}
+	for param, dest := range map[string]*int{
+		"year":  &filter.Year,
+		"limit": &filter.Limit,
+	} {
+		if !values.Has(param) {
+			continue
+		}
+		value, err := strconv.Atoi(values.Get(param))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s", param)
+		}
+		*dest = value
+	}
+	return filter, nil
+}
+
+/* list info and movie ID of /list/{id}/... routes */
+func listUnitFromRequest(r *http.Request) (*model.ListUnit, error) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	movieID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ListUnit{Movie: model.Movie{ID: movieID}, ListInfo: *list}, nil
+}
+
+func entityTag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+/* The version from If-Match header, nil means that any version matches */
+func versionFromRequest(r *http.Request) (*int64, error) {
+	header := r.Header.Get("If-Match")
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return nil, fmt.Errorf("invalid If-Match header")
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid If-Match header")
+	}
+	return &version, nil
+}
@@ -0,0 +1,924 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_addMovie(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, movie *model.ListUnit)
+	type testCase struct {
+		name                 string
+		userID               int64
+		inputBody            string
+		inputMovie           model.ListUnit
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:      "OK",
+			userID:    42,
+			inputBody: `{"name":"Dune","status":"plan to watch"}`,
+			inputMovie: model.ListUnit{
+				Movie:    model.Movie{Name: "Dune"},
+				Status:   "plan to watch",
+				ListInfo: model.ListInfo{OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockListService, movie *model.ListUnit) {
+				s.EXPECT().AddMovie(movie).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "Movie Dune has successfully added to user's 42 list",
+		},
+		{
+			name:      "Already in list",
+			userID:    42,
+			inputBody: `{"name":"Dune","status":"completed","score":9}`,
+			inputMovie: model.ListUnit{
+				Movie:    model.Movie{Name: "Dune"},
+				Status:   "completed",
+				Score:    9,
+				ListInfo: model.ListInfo{OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockListService, movie *model.ListUnit) {
+				s.EXPECT().AddMovie(movie).Return(&model.ConflictError{Message: "movie is already in your list"})
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"error\":\"movie is already in your list\"}\n",
+		},
+		{
+			name:      "In trash",
+			userID:    42,
+			inputBody: `{"id":301,"status":"completed"}`,
+			inputMovie: model.ListUnit{
+				Movie:    model.Movie{ID: 301},
+				Status:   "completed",
+				ListInfo: model.ListInfo{OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockListService, movie *model.ListUnit) {
+				s.EXPECT().AddMovie(movie).Return(&model.ConflictError{
+					Message: "movie is in the trash, restore it with POST /list/trash/301/restore"})
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"error\":\"movie is in the trash, restore it with POST /list/trash/301/restore\"}\n",
+		},
+		{
+			name:      "Invalid status",
+			userID:    42,
+			inputBody: `{"name":"Dune","status":"rewatching"}`,
+			inputMovie: model.ListUnit{
+				Movie:    model.Movie{Name: "Dune"},
+				Status:   "rewatching",
+				ListInfo: model.ListInfo{OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockListService, movie *model.ListUnit) {
+				s.EXPECT().AddMovie(movie).Return(fmt.Errorf("invalid title status"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid title status\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputMovie)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list", handler.addMovie).Methods(http.MethodPost)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, "/list", bytes.NewBufferString(tc.inputBody))
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, tc.userID))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_getMovies(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, filter *model.ListFilter)
+	type testCase struct {
+		name                 string
+		target               string
+		inputFilter          model.ListFilter
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	var (
+		isFavorite = true
+		minScore   = 7.0
+	)
+	testCases := []testCase{
+		{
+			name:        "Default list",
+			target:      "/list",
+			inputFilter: model.ListFilter{ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, filter *model.ListFilter) {
+				s.EXPECT().GetMovies(filter).Return(&model.ListPage{
+					Movies: []*model.ListUnit{{
+						Movie:     model.Movie{ID: 409424, Name: "Дюна"},
+						Status:    "completed",
+						Score:     8,
+						AddedAt:   time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC),
+						UpdatedAt: time.Date(2023, time.July, 3, 18, 30, 0, 0, time.UTC),
+						Version:   3,
+					}},
+					NextCursor: "WyI4IiwiNDA5NDI0Il0",
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"movies\":[{\"id\":409424,\"name\":\"Дюна\",\"status\":\"completed\",\"score\":8,\"is_favorite\":false,\"added_at\":\"2023-07-01T12:00:00Z\",\"updated_at\":\"2023-07-03T18:30:00Z\",\"version\":3}],\"next_cursor\":\"WyI4IiwiNDA5NDI0Il0\"}\n",
+		},
+		{
+			name:   "Named list with filters",
+			target: "/lists/7/movies?status=completed&favorite=true&min_score=7&genre=драма&tag=cinema&tag=christmas&sort=name&order=asc&cursor=abc&limit=20",
+			inputFilter: model.ListFilter{
+				ListInfo:   model.ListInfo{ListID: 7, OwnerID: 42},
+				Status:     "completed",
+				IsFavorite: &isFavorite,
+				MinScore:   &minScore,
+				Genre:      "драма",
+				Tags:       []string{"cinema", "christmas"},
+				Sort:       "name",
+				Order:      "asc",
+				Cursor:     "abc",
+				Limit:      20,
+			},
+			mockBehavior: func(s *mock_service.MockListService, filter *model.ListFilter) {
+				s.EXPECT().GetMovies(filter).Return(&model.ListPage{Movies: []*model.ListUnit{}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"movies\":[]}\n",
+		},
+		{
+			name:                 "Invalid score",
+			target:               "/list?max_score=ten",
+			mockBehavior:         func(s *mock_service.MockListService, filter *model.ListFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid max_score\"}\n",
+		},
+		{
+			name:        "Someone else's list",
+			target:      "/lists/8/movies",
+			inputFilter: model.ListFilter{ListInfo: model.ListInfo{ListID: 8, OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, filter *model.ListFilter) {
+				s.EXPECT().GetMovies(filter).Return(nil, &model.NotFoundError{Message: "list 8 doesn't exist"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"list 8 doesn't exist\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputFilter)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			routes := &movieRoutes{list: handler}
+			routes.register(router.PathPrefix("/list").Subrouter())
+			routes.register(router.PathPrefix("/lists/{listID:[0-9]+}/movies").Subrouter())
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_updateMovie(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, movie *model.ListUnitPatch)
+	type testCase struct {
+		name                 string
+		ifMatch              string
+		inputBody            string
+		inputMovie           model.ListUnitPatch
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedETag         string
+		expectedResponseBody string
+	}
+	var (
+		ownerID  = int64(42)
+		movieID  = int64(409424)
+		score    = 9.0
+		version  = int64(3)
+		newScore = func(s *mock_service.MockListService, movie *model.ListUnitPatch) {
+			s.EXPECT().UpdateMovie(movie).DoAndReturn(func(movie *model.ListUnitPatch) error {
+				version := int64(4)
+				movie.Version = &version
+				return nil
+			})
+		}
+	)
+	testCases := []testCase{
+		{
+			name:                 "Without If-Match",
+			inputBody:            `{"score":9}`,
+			inputMovie:           model.ListUnitPatch{OwnerID: &ownerID, MovieID: &movieID, Score: &score},
+			mockBehavior:         newScore,
+			expectedStatusCode:   http.StatusOK,
+			expectedETag:         `"4"`,
+			expectedResponseBody: "movie data has been updated",
+		},
+		{
+			name:                 "Matching version",
+			ifMatch:              `"3"`,
+			inputBody:            `{"score":9}`,
+			inputMovie:           model.ListUnitPatch{OwnerID: &ownerID, MovieID: &movieID, Score: &score, Version: &version},
+			mockBehavior:         newScore,
+			expectedStatusCode:   http.StatusOK,
+			expectedETag:         `"4"`,
+			expectedResponseBody: "movie data has been updated",
+		},
+		{
+			name:       "Outdated version",
+			ifMatch:    `"3"`,
+			inputBody:  `{"score":9}`,
+			inputMovie: model.ListUnitPatch{OwnerID: &ownerID, MovieID: &movieID, Score: &score, Version: &version},
+			mockBehavior: func(s *mock_service.MockListService, movie *model.ListUnitPatch) {
+				s.EXPECT().UpdateMovie(movie).Return(&model.PreconditionFailedError{
+					Message: "movie has been changed, its current version is 5",
+				})
+			},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: "{\"error\":\"movie has been changed, its current version is 5\"}\n",
+		},
+		{
+			name:                 "Invalid If-Match",
+			ifMatch:              "three",
+			inputBody:            `{"score":9}`,
+			mockBehavior:         func(s *mock_service.MockListService, movie *model.ListUnitPatch) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid If-Match header\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputMovie)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list/{id:[0-9]+}", handler.updateMovie).Methods(http.MethodPatch)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPatch, "/list/409424", bytes.NewBufferString(tc.inputBody))
+			)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, ownerID))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedETag, w.Header().Get("ETag"))
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_incrementProgress(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, movie *model.ListUnit, inc *model.ProgressIncrement)
+	type testCase struct {
+		name                 string
+		inputBody            string
+		inputMovie           model.ListUnit
+		inputIncrement       model.ProgressIncrement
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:       "Without body",
+			inputMovie: model.ListUnit{Movie: model.Movie{ID: 464963}, ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, movie *model.ListUnit, inc *model.ProgressIncrement) {
+				s.EXPECT().IncrementProgress(movie, inc).DoAndReturn(func(movie *model.ListUnit, inc *model.ProgressIncrement) error {
+					movie.Name = "Игра престолов"
+					movie.IsSeries = true
+					movie.Status = "watching"
+					movie.Progress = &model.Progress{Season: 1, Episode: 1, TotalSeasons: 1,
+						SeasonEpisodes: 10, WatchedEpisodes: 1, TotalEpisodes: 10}
+					movie.Version = 2
+					return nil
+				})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":464963,\"name\":\"Игра престолов\",\"is_series\":true,\"status\":\"watching\",\"score\":0,\"is_favorite\":false,\"added_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\",\"progress\":{\"season\":1,\"episode\":1,\"total_seasons\":1,\"season_episodes\":10,\"watched_episodes\":1,\"total_episodes\":10},\"version\":2}\n",
+		},
+		{
+			name:           "Not a series",
+			inputBody:      `{"episodes":3}`,
+			inputMovie:     model.ListUnit{Movie: model.Movie{ID: 409424}, ListInfo: model.ListInfo{OwnerID: 42}},
+			inputIncrement: model.ProgressIncrement{Episodes: 3},
+			mockBehavior: func(s *mock_service.MockListService, movie *model.ListUnit, inc *model.ProgressIncrement) {
+				s.EXPECT().IncrementProgress(movie, inc).Return(fmt.Errorf("movie 409424 isn't a series"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"movie 409424 isn't a series\"}\n",
+		},
+		{
+			name:                 "Invalid body",
+			inputBody:            `{"episodes":"three"}`,
+			mockBehavior:         func(s *mock_service.MockListService, movie *model.ListUnit, inc *model.ProgressIncrement) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"json: cannot unmarshal string into Go struct field ProgressIncrement.episodes of type int\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputMovie, &tc.inputIncrement)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list/{id:[0-9]+}/progress", handler.incrementProgress).Methods(http.MethodPost)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/list/%d/progress", tc.inputMovie.ID),
+					bytes.NewBufferString(tc.inputBody))
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_importMovies(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, imp *model.Import)
+	type testCase struct {
+		name                 string
+		format               string
+		inputFile            string
+		inputImport          model.Import
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	createdAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []testCase{
+		{
+			name:   "Letterboxd ratings",
+			format: model.ImportLetterboxdRatings,
+			inputFile: "Date,Name,Year,Letterboxd URI,Rating\n" +
+				"2023-01-02,The Matrix,1999,https://boxd.it/28Q8,4.5\n" +
+				"2023-01-03,Solaris,,https://boxd.it/2b0k,3\n",
+			inputImport: model.Import{
+				ListInfo: model.ListInfo{OwnerID: 42},
+				Format:   model.ImportLetterboxdRatings,
+				Rows: []*model.ImportRow{
+					{Line: 2, Title: "The Matrix", Year: 1999, Status: "completed", RawScore: 90},
+					{Line: 3, Title: "Solaris", Status: "completed", RawScore: 60},
+				},
+			},
+			mockBehavior: func(s *mock_service.MockListService, imp *model.Import) {
+				s.EXPECT().ImportMovies(imp).Return(&model.ImportProgress{
+					ID: 7, Format: imp.Format, Total: 2, CreatedAt: createdAt, ImportReport: *model.NewImportReport(),
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusAccepted,
+			expectedResponseBody: "{\"id\":7,\"format\":\"letterboxd_ratings\",\"total\":2,\"processed\":0,\"created_at\":\"2024-03-01T00:00:00Z\",\"matched\":[],\"ambiguous\":[],\"failed\":[]}\n",
+		},
+		{
+			name:   "IMDb ratings",
+			format: model.ImportIMDbRatings,
+			inputFile: "\ufeffConst,Your Rating,Date Rated,Title,URL,Title Type,IMDb Rating,Runtime (mins),Year\n" +
+				"tt0133093,10,2023-01-02,The Matrix,https://www.imdb.com/title/tt0133093/,movie,8.7,136,1999\n" +
+				"tt0000000,eleven,2023-01-02,Nothing,,movie,,,\n",
+			inputImport: model.Import{
+				ListInfo: model.ListInfo{OwnerID: 42},
+				Format:   model.ImportIMDbRatings,
+				Rows: []*model.ImportRow{
+					{Line: 2, Title: "The Matrix", Year: 1999, IMDbID: "tt0133093", Status: "completed", RawScore: 100},
+					{Line: 3, Title: "Nothing", IMDbID: "tt0000000", Status: "completed", Error: "invalid score \"eleven\""},
+				},
+			},
+			mockBehavior: func(s *mock_service.MockListService, imp *model.Import) {
+				s.EXPECT().ImportMovies(imp).Return(&model.ImportProgress{
+					ID: 8, Format: imp.Format, Total: 2, CreatedAt: createdAt, ImportReport: *model.NewImportReport(),
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusAccepted,
+			expectedResponseBody: "{\"id\":8,\"format\":\"imdb_ratings\",\"total\":2,\"processed\":0,\"created_at\":\"2024-03-01T00:00:00Z\",\"matched\":[],\"ambiguous\":[],\"failed\":[]}\n",
+		},
+		{
+			name:                 "Wrong file",
+			format:               model.ImportIMDbRatings,
+			inputFile:            "Date,Name,Year,Letterboxd URI\n2023-01-02,The Matrix,1999,https://boxd.it/28Q8\n",
+			mockBehavior:         func(s *mock_service.MockListService, imp *model.Import) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"there's no \\\"Title\\\" column in the file\"}\n",
+		},
+		{
+			name:                 "Invalid format",
+			format:               "kinopoisk",
+			inputFile:            "Name\n",
+			mockBehavior:         func(s *mock_service.MockListService, imp *model.Import) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid format, it must be one of letterboxd_watched, letterboxd_ratings, letterboxd_watchlist, imdb_ratings\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputImport)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list/import", handler.importMovies).Methods(http.MethodPost)
+			var (
+				body   = new(bytes.Buffer)
+				writer = multipart.NewWriter(body)
+			)
+			part, err := writer.CreateFormFile("file", "ratings.csv")
+			assert.NoError(t, err)
+			part.Write([]byte(tc.inputFile))
+			writer.Close()
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, "/list/import?format="+tc.format, body)
+			)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_getImport(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService)
+	type testCase struct {
+		name                 string
+		target               string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	var (
+		createdAt  = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		finishedAt = time.Date(2024, 3, 1, 0, 1, 0, 0, time.UTC)
+	)
+	testCases := []testCase{
+		{
+			name:   "OK",
+			target: "/list/import/7",
+			mockBehavior: func(s *mock_service.MockListService) {
+				progress := &model.ImportProgress{
+					ID: 7, Format: model.ImportLetterboxdRatings, Total: 3, Processed: 3,
+					CreatedAt: createdAt, FinishedAt: &finishedAt, ImportReport: *model.NewImportReport(),
+				}
+				progress.Add(&model.ImportRow{
+					Line: 2, Title: "The Matrix", Year: 1999, Status: "completed", Score: 9,
+					Movie: &model.Movie{ID: 301, Name: "Матрица"},
+				})
+				progress.Add(&model.ImportRow{
+					Line: 3, Title: "Solaris", Status: "completed", Score: 6,
+					Candidates: []model.Movie{{ID: 43911, Name: "Солярис", Year: 1972}, {ID: 6184, Name: "Солярис", Year: 2002}},
+				})
+				progress.Add(&model.ImportRow{
+					Line: 4, Title: "Nothing", IMDbID: "tt0000000", Status: "completed", Error: "movie \"Nothing\" not found",
+				})
+				s.EXPECT().GetImport(int64(42), int64(7)).Return(progress, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":7,\"format\":\"letterboxd_ratings\",\"total\":3,\"processed\":3,\"created_at\":\"2024-03-01T00:00:00Z\",\"finished_at\":\"2024-03-01T00:01:00Z\",\"matched\":[{\"line\":2,\"title\":\"The Matrix\",\"year\":1999,\"status\":\"completed\",\"score\":9,\"movie\":{\"id\":301,\"name\":\"Матрица\"}}],\"ambiguous\":[{\"line\":3,\"title\":\"Solaris\",\"status\":\"completed\",\"score\":6,\"candidates\":[{\"id\":43911,\"name\":\"Солярис\",\"year\":1972},{\"id\":6184,\"name\":\"Солярис\",\"year\":2002}]}],\"failed\":[{\"line\":4,\"title\":\"Nothing\",\"imdb_id\":\"tt0000000\",\"status\":\"completed\",\"score\":0,\"error\":\"movie \\\"Nothing\\\" not found\"}]}\n",
+		},
+		{
+			name:   "Not found",
+			target: "/list/import/9",
+			mockBehavior: func(s *mock_service.MockListService) {
+				s.EXPECT().GetImport(int64(42), int64(9)).Return(nil, &model.NotFoundError{Message: "import 9 doesn't exist"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"import 9 doesn't exist\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list/import/{id:[0-9]+}", handler.getImport).Methods(http.MethodGet)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_batchMovies(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, batch *model.Batch)
+	type testCase struct {
+		name                 string
+		inputBody            string
+		inputBatch           model.Batch
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	var (
+		completed         = "completed"
+		score     float64 = 9
+		version   int64   = 3
+		newVer    int64   = 4
+		addedVer  int64   = 1
+		batchBody string  = `{"operations":[{"op":"add","movie_id":301,"status":"completed"},` +
+			`{"op":"update","movie_id":326,"score":9,"version":3},{"op":"delete","movie_id":535341}]}`
+	)
+	testCases := []testCase{
+		{
+			name:      "OK",
+			inputBody: batchBody,
+			inputBatch: model.Batch{
+				Operations: []*model.BatchOperation{
+					{Op: model.BatchAdd, MovieID: 301, Status: &completed},
+					{Op: model.BatchUpdate, MovieID: 326, Score: &score, Version: &version},
+					{Op: model.BatchDelete, MovieID: 535341},
+				},
+				ListInfo: model.ListInfo{OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockListService, batch *model.Batch) {
+				s.EXPECT().BatchMovies(batch).Return(&model.BatchReport{
+					Applied: 2,
+					Failed:  1,
+					Results: []*model.BatchResult{
+						{Index: 0, Op: model.BatchAdd, MovieID: 301, Applied: true, Version: &addedVer},
+						{Index: 1, Op: model.BatchUpdate, MovieID: 326, Applied: true, Version: &newVer},
+						{Index: 2, Op: model.BatchDelete, MovieID: 535341,
+							Err: &model.NotFoundError{Message: "movie 535341 isn't in the list"}},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "{\"applied\":2,\"failed\":1,\"rolled_back\":false,\"results\":[" +
+				"{\"index\":0,\"op\":\"add\",\"movie_id\":301,\"applied\":true,\"version\":1}," +
+				"{\"index\":1,\"op\":\"update\",\"movie_id\":326,\"applied\":true,\"version\":4}," +
+				"{\"index\":2,\"op\":\"delete\",\"movie_id\":535341,\"applied\":false,\"code\":404,\"error\":\"movie 535341 isn't in the list\"}]}\n",
+		},
+		{
+			name:      "Rolled back",
+			inputBody: `{"operations":[{"op":"update","movie_id":326,"score":9,"version":3}],"atomic":true}`,
+			inputBatch: model.Batch{
+				Operations: []*model.BatchOperation{
+					{Op: model.BatchUpdate, MovieID: 326, Score: &score, Version: &version},
+				},
+				Atomic:   true,
+				ListInfo: model.ListInfo{OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockListService, batch *model.Batch) {
+				s.EXPECT().BatchMovies(batch).Return(&model.BatchReport{
+					Failed:     1,
+					RolledBack: true,
+					Results: []*model.BatchResult{
+						{Index: 0, Op: model.BatchUpdate, MovieID: 326,
+							Err: &model.PreconditionFailedError{Message: "movie has been changed, its current version is 5"}},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "{\"applied\":0,\"failed\":1,\"rolled_back\":true,\"results\":[" +
+				"{\"index\":0,\"op\":\"update\",\"movie_id\":326,\"applied\":false,\"code\":412,\"error\":\"movie has been changed, its current version is 5\"}]}\n",
+		},
+		{
+			name:      "Unknown operation",
+			inputBody: `{"operations":[{"op":"restore","movie_id":326}]}`,
+			inputBatch: model.Batch{
+				Operations: []*model.BatchOperation{{Op: "restore", MovieID: 326}},
+				ListInfo:   model.ListInfo{OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockListService, batch *model.Batch) {
+				s.EXPECT().BatchMovies(batch).Return(nil, fmt.Errorf("operation 0: unknown operation \"restore\""))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"operation 0: unknown operation \\\"restore\\\"\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputBatch)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list/batch", handler.batchMovies).Methods(http.MethodPost)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, "/list/batch", bytes.NewBufferString(tc.inputBody))
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_moveMovie(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, move *model.RankMove)
+	type testCase struct {
+		name                 string
+		inputBody            string
+		inputMove            model.RankMove
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	var target int64 = 326
+	testCases := []testCase{
+		{
+			name:      "OK",
+			inputBody: `{"after":326}`,
+			inputMove: model.RankMove{MovieID: 301, After: &target, ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, move *model.RankMove) {
+				s.EXPECT().MoveMovie(move).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "movie has been moved",
+		},
+		{
+			name:      "Target isn't in list",
+			inputBody: `{"before":326}`,
+			inputMove: model.RankMove{MovieID: 301, Before: &target, ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, move *model.RankMove) {
+				s.EXPECT().MoveMovie(move).Return(&model.NotFoundError{Message: "movie 326 isn't in the list"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"movie 326 isn't in the list\"}\n",
+		},
+		{
+			name:      "No target",
+			inputBody: `{}`,
+			inputMove: model.RankMove{MovieID: 301, ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, move *model.RankMove) {
+				s.EXPECT().MoveMovie(move).Return(fmt.Errorf("either before or after must be specified"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"either before or after must be specified\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputMove)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list/{id:[0-9]+}/move", handler.moveMovie).Methods(http.MethodPost)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, "/list/301/move", bytes.NewBufferString(tc.inputBody))
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_planCollection(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, plan *model.CollectionPlan)
+	type testCase struct {
+		name                 string
+		target               string
+		inputPlan            model.CollectionPlan
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:      "OK",
+			target:    "/lists/7/movies/collections/301/plan",
+			inputPlan: model.CollectionPlan{MovieID: 301, ListInfo: model.ListInfo{ListID: 7, OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, plan *model.CollectionPlan) {
+				s.EXPECT().PlanCollection(plan).Return([]*model.ListUnit{{
+					Movie:   model.Movie{ID: 1309325, Name: "Матрица: Воскрешение", Year: 2021},
+					Status:  "plan to watch",
+					Version: 1,
+				}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "[{\"id\":1309325,\"name\":\"Матрица: Воскрешение\",\"year\":2021,\"status\":\"plan to watch\",\"score\":0,\"is_favorite\":false,\"added_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\",\"version\":1}]\n",
+		},
+		{
+			name:      "No collection",
+			target:    "/lists/7/movies/collections/326/plan",
+			inputPlan: model.CollectionPlan{MovieID: 326, ListInfo: model.ListInfo{ListID: 7, OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, plan *model.CollectionPlan) {
+				s.EXPECT().PlanCollection(plan).
+					Return(nil, &model.NotFoundError{Message: "movie 326 isn't in any collection of the list"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"movie 326 isn't in any collection of the list\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputPlan)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/lists/{listID:[0-9]+}/movies/collections/{id:[0-9]+}/plan", handler.planCollection).
+				Methods(http.MethodPost)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_getTrash(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, list *model.ListInfo)
+	type testCase struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	var (
+		addedAt   = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		deletedAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	)
+	testCases := []testCase{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_service.MockListService, list *model.ListInfo) {
+				s.EXPECT().GetTrash(list).Return([]*model.ListUnit{{
+					Movie:     model.Movie{ID: 301, Name: "Матрица"},
+					Status:    "completed",
+					Score:     9,
+					AddedAt:   addedAt,
+					UpdatedAt: deletedAt,
+					DeletedAt: &deletedAt,
+					Version:   3,
+				}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "[{\"id\":301,\"name\":\"Матрица\",\"status\":\"completed\",\"score\":9,\"is_favorite\":false,\"added_at\":\"2024-02-01T00:00:00Z\",\"updated_at\":\"2024-03-01T00:00:00Z\",\"deleted_at\":\"2024-03-01T00:00:00Z\",\"version\":3}]\n",
+		},
+		{
+			name: "List not found",
+			mockBehavior: func(s *mock_service.MockListService, list *model.ListInfo) {
+				s.EXPECT().GetTrash(list).Return(nil, &model.NotFoundError{Message: "list 0 doesn't exist"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"list 0 doesn't exist\"}\n",
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(s *mock_service.MockListService, list *model.ListInfo) {
+				s.EXPECT().GetTrash(list).Return(nil, fmt.Errorf("connection refused"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"error\":\"connection refused\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &model.ListInfo{OwnerID: 42})
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list/trash", handler.getTrash).Methods(http.MethodGet)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, "/list/trash", nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_restoreMovie(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, movie *model.ListUnit)
+	type testCase struct {
+		name                 string
+		target               string
+		inputMovie           model.ListUnit
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	addedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []testCase{
+		{
+			name:       "OK",
+			target:     "/list/trash/301/restore",
+			inputMovie: model.ListUnit{Movie: model.Movie{ID: 301}, ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, movie *model.ListUnit) {
+				s.EXPECT().RestoreMovie(movie).DoAndReturn(func(movie *model.ListUnit) error {
+					movie.Name, movie.Status, movie.Score = "Матрица", "completed", 9
+					movie.AddedAt, movie.UpdatedAt, movie.Version = addedAt, addedAt, 4
+					return nil
+				})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":301,\"name\":\"Матрица\",\"status\":\"completed\",\"score\":9,\"is_favorite\":false,\"added_at\":\"2024-02-01T00:00:00Z\",\"updated_at\":\"2024-02-01T00:00:00Z\",\"version\":4}\n",
+		},
+		{
+			name:       "Not in trash",
+			target:     "/list/trash/326/restore",
+			inputMovie: model.ListUnit{Movie: model.Movie{ID: 326}, ListInfo: model.ListInfo{OwnerID: 42}},
+			mockBehavior: func(s *mock_service.MockListService, movie *model.ListUnit) {
+				s.EXPECT().RestoreMovie(movie).Return(&model.NotFoundError{Message: "movie 326 isn't in the trash"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"movie 326 isn't in the trash\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputMovie)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/list/trash/{id:[0-9]+}/restore", handler.restoreMovie).Methods(http.MethodPost)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+// CreateList godoc
+// @Summary      Create list
+// @Security	 AccessToken
+// @Description  Create a named movie list, e.g. "Horror marathon" or "Watch with kids". Movies of the list are managed via /lists/{listID}/movies routes, which are the same as /list ones
+// @Tags         lists
+// @Accept       json
+// @Produce      json
+// @Param 		 input body model.List true "list info"
+// @Success      201      {object}  model.List
+// @Failure      400,404  {object}  errorResponse
+// @Failure      409      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /lists [post]
+func (h *listHandler) createList(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	req := new(model.List)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.OwnerID = userID
+	if err := h.service.CreateList(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusCreated, req)
+}
+
+// GetLists godoc
+// @Summary      Get lists
+// @Security	 AccessToken
+// @Description  Get all lists of the user, the default one goes first
+// @Tags         lists
+// @Produce      json
+// @Success      200      {array}   model.List
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /lists [get]
+func (h *listHandler) getLists(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	lists, err := h.service.GetLists(userID)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, lists)
+}
+
+// GetList godoc
+// @Summary      Get list
+// @Security	 AccessToken
+// @Description  Get list name, description, etc.
+// @Tags         lists
+// @Produce      json
+// @Param 		 listID path int true "List ID"
+// @Success      200      {object}  model.List
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /lists/{listID} [get]
+func (h *listHandler) getList(w http.ResponseWriter, r *http.Request) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp, err := h.service.GetList(list.ListID, list.OwnerID)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, resp)
+}
+
+// UpdateList godoc
+// @Summary      Update list
+// @Security	 AccessToken
+// @Description  Rename the list, change its description or visibility: private (default), unlisted (available by the link /u/{username}/lists/{slug}) or public (also shown on the profile)
+// @Tags         lists
+// @Accept       json
+// @Produce      json
+// @Param 		 listID path int true "List ID"
+// @Param 		 input body model.ListPatch true "list info"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      409      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /lists/{listID} [patch]
+func (h *listHandler) updateList(w http.ResponseWriter, r *http.Request) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.ListPatch)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.ID = &list.ListID
+	req.OwnerID = &list.OwnerID
+	if err := h.service.UpdateList(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("list data has been updated"))
+}
+
+// DeleteList godoc
+// @Summary      Delete list
+// @Security	 AccessToken
+// @Description  Delete the list with all its movies. The default list cannot be deleted
+// @Tags         lists
+// @Produce      json
+// @Param 		 listID path int true "List ID"
+// @Success      200      {object}  model.List
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /lists/{listID} [delete]
+func (h *listHandler) deleteList(w http.ResponseWriter, r *http.Request) {
+	list, err := listInfoFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp, err := h.service.DeleteList(list.ListID, list.OwnerID)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, resp)
+}
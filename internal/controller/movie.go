@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+// SearchMovies godoc
+// @Summary      Search movies
+// @Security	 AccessToken
+// @Description  Search movies by title via a third-party API. Use the ID of the right candidate to add the movie to the list. The API can't filter by year, so the year only narrows down the candidates of the requested page: pages may be short or even empty, page and pages still refer to the whole search, and total is left out as it isn't known
+// @Tags         movies
+// @Produce      json
+// @Param 		 q     query string true  "Movie title"
+// @Param 		 page  query int    false "Page number" default(1)
+// @Param 		 limit query int    false "Page size" default(10) maximum(50)
+// @Param 		 year  query int    false "Release year"
+// @Success      200      {object}  model.SearchResult
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /movies/search [get]
+func (h *listHandler) searchMovies(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	query := &model.SearchQuery{Query: values.Get("q")}
+	for param, dest := range map[string]*int{
+		"page":  &query.Page,
+		"limit": &query.Limit,
+		"year":  &query.Year,
+	} {
+		if !values.Has(param) {
+			continue
+		}
+		value, err := strconv.Atoi(values.Get(param))
+		if err != nil {
+			writeErrorJSON(w, http.StatusBadRequest, "invalid "+param)
+			return
+		}
+		*dest = value
+	}
+	result, err := h.service.SearchMovies(query)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, result)
+}
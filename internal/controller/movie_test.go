@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_searchMovies(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, query *model.SearchQuery)
+	type testCase struct {
+		name                 string
+		target               string
+		inputQuery           model.SearchQuery
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:       "OK",
+			target:     "/movies/search?q=Dune&page=2&limit=5",
+			inputQuery: model.SearchQuery{Query: "Dune", Page: 2, Limit: 5},
+			mockBehavior: func(s *mock_service.MockListService, query *model.SearchQuery) {
+				s.EXPECT().SearchMovies(query).Return(&model.SearchResult{
+					Docs:  []model.Movie{{ID: 5029, Name: "Дюна", Year: 1984}, {ID: 409424, Name: "Дюна", Year: 2021}},
+					Total: 7, Limit: 5, Page: 2, Pages: 2,
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"docs\":[{\"id\":5029,\"name\":\"Дюна\",\"year\":1984},{\"id\":409424,\"name\":\"Дюна\",\"year\":2021}],\"total\":7,\"limit\":5,\"page\":2,\"pages\":2}\n",
+		},
+		{
+			name:       "Year",
+			target:     "/movies/search?q=Dune&page=2&limit=5&year=1984",
+			inputQuery: model.SearchQuery{Query: "Dune", Page: 2, Limit: 5, Year: 1984},
+			mockBehavior: func(s *mock_service.MockListService, query *model.SearchQuery) {
+				s.EXPECT().SearchMovies(query).Return(&model.SearchResult{
+					Docs:  []model.Movie{{ID: 5029, Name: "Дюна", Year: 1984}},
+					Limit: 5, Page: 2, Pages: 2,
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"docs\":[{\"id\":5029,\"name\":\"Дюна\",\"year\":1984}],\"limit\":5,\"page\":2,\"pages\":2}\n",
+		},
+		{
+			name:                 "Invalid page",
+			target:               "/movies/search?q=Dune&page=two",
+			mockBehavior:         func(s *mock_service.MockListService, query *model.SearchQuery) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid page\"}\n",
+		},
+		{
+			name:       "Empty query",
+			target:     "/movies/search",
+			inputQuery: model.SearchQuery{},
+			mockBehavior: func(s *mock_service.MockListService, query *model.SearchQuery) {
+				s.EXPECT().SearchMovies(query).Return(nil, fmt.Errorf("empty search query"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"empty search query\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputQuery)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/movies/search", handler.searchMovies).Methods(http.MethodGet)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, tc.target, nil)
+			)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
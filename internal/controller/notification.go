@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/service"
+)
+
+type notificationHandler struct {
+	service service.NotificationService
+}
+
+// GetNotifications godoc
+// @Summary      Get notifications
+// @Security	 AccessToken
+// @Description  Get the notifications that haven't been dismissed, newest first. A notification is created when the premiere or the digital release of a movie planned to watch comes; it's also emailed if email_notifications setting is on (see PATCH /user/{id})
+// @Tags         notifications
+// @Produce      json
+// @Success      200      {array}   model.Notification
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /notifications [get]
+func (h *notificationHandler) getNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	notifications, err := h.service.GetNotifications(userID)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, notifications)
+}
+
+// DismissNotification godoc
+// @Summary      Dismiss notification
+// @Security	 AccessToken
+// @Description  Hide the notification, it won't be created again
+// @Tags         notifications
+// @Produce      json
+// @Param 		 id path int true "Notification ID"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /notifications/{id} [delete]
+func (h *notificationHandler) dismissNotification(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DismissNotification(userID, id); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("notification has been dismissed"))
+}
+
+// DismissNotifications godoc
+// @Summary      Dismiss all notifications
+// @Security	 AccessToken
+// @Description  Hide all notifications of the user
+// @Tags         notifications
+// @Produce      json
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /notifications [delete]
+func (h *notificationHandler) dismissNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	count, err := h.service.DismissNotifications(userID)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(fmt.Sprintf("%d notifications have been dismissed", count)))
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_getNotifications(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockNotificationService)
+	type testCase struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	var (
+		releaseDate = time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+		createdAt   = time.Date(2024, 2, 29, 3, 0, 0, 0, time.UTC)
+	)
+	testCases := []testCase{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_service.MockNotificationService) {
+				s.EXPECT().GetNotifications(int64(42)).Return([]*model.Notification{{
+					ID:          5,
+					UserID:      42,
+					Kind:        model.NotificationPremiere,
+					Movie:       model.Movie{ID: 507, Name: "Дюна: Часть вторая", Premiere: &releaseDate},
+					ReleaseDate: releaseDate,
+					CreatedAt:   createdAt,
+				}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "[{\"id\":5,\"kind\":\"premiere\",\"movie\":{\"id\":507,\"name\":\"Дюна: Часть вторая\",\"premiere\":\"2024-02-29T00:00:00Z\"},\"release_date\":\"2024-02-29T00:00:00Z\",\"created_at\":\"2024-02-29T03:00:00Z\"}]\n",
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(s *mock_service.MockNotificationService) {
+				s.EXPECT().GetNotifications(int64(42)).Return(nil, fmt.Errorf("connection refused"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"error\":\"connection refused\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			notification := mock_service.NewMockNotificationService(c)
+			tc.mockBehavior(notification)
+			var (
+				handler = &notificationHandler{service: notification}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/notifications", handler.getNotifications).Methods(http.MethodGet)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, "/notifications", nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_dismissNotification(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockNotificationService)
+	type testCase struct {
+		name                 string
+		target               string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:   "OK",
+			target: "/notifications/5",
+			mockBehavior: func(s *mock_service.MockNotificationService) {
+				s.EXPECT().DismissNotification(int64(42), int64(5)).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "notification has been dismissed",
+		},
+		{
+			name:   "Not found",
+			target: "/notifications/6",
+			mockBehavior: func(s *mock_service.MockNotificationService) {
+				s.EXPECT().DismissNotification(int64(42), int64(6)).
+					Return(&model.NotFoundError{Message: "notification 6 doesn't exist"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"notification 6 doesn't exist\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			notification := mock_service.NewMockNotificationService(c)
+			tc.mockBehavior(notification)
+			var (
+				handler = &notificationHandler{service: notification}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/notifications/{id:[0-9]+}", handler.dismissNotification).Methods(http.MethodDelete)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodDelete, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/kiryu-dev/mykinolist/internal/service"
+)
+
+type profileHandler struct {
+	service service.ProfileService
+}
+
+// UpdateSettings godoc
+// @Summary      Update account settings
+// @Security	 AccessToken
+// @Description  Change visibility of the profile: private (default) profiles are seen by nobody but the owner, unlisted and public ones are available at /u/{username}. Choose the score scale: ten (1-10, default), five_stars (0.5-5 in half stars) or hundred (1-100); all scores are shown and entered on this scale, the ones rated on another scale are rounded to its step. Turn on email_notifications to get release reminders (see /notifications) by email too
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param 		 id path int true "User ID"
+// @Param 		 input body model.UserSettings true "settings"
+// @Success      200      {object}  model.User
+// @Failure      400,404  {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /user/{id} [patch]
+func (h *profileHandler) updateSettings(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	idFromCtx := r.Context().Value(userIDKey{}).(int64)
+	if id != idFromCtx {
+		writeErrorJSON(w, http.StatusForbidden, "cannot change someone else's settings")
+		return
+	}
+	req := new(model.UserSettings)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.ID = id
+	user, err := h.service.UpdateSettings(req)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, user)
+}
+
+// GetProfile godoc
+// @Summary      Get public profile
+// @Description  Get the profile of the user with their public lists. Private profiles are reported as nonexistent
+// @Tags         profiles
+// @Produce      json
+// @Param 		 username path string true "Username"
+// @Success      200      {object}  model.Profile
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /u/{username} [get]
+func (h *profileHandler) getProfile(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.service.GetProfile(mux.Vars(r)["username"])
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, profile)
+}
+
+// GetPublicList godoc
+// @Summary      Get public list
+// @Description  Get the unlisted or public list of the user page by page. Filters, sorting and paging are the same as of the owner's list
+// @Tags         profiles
+// @Produce      json
+// @Param 		 username   path  string true  "Username"
+// @Param 		 slug       path  string true  "Slug of the list"
+// @Param 		 status     query string false "Movie status"
+// @Param 		 favorite   query bool   false "Only favorites (true) or only not favorites (false)"
+// @Param 		 min_score  query number false "Min score, up to the max of the user's score scale"
+// @Param 		 max_score  query number false "Max score, up to the max of the user's score scale"
+// @Param 		 genre      query string false "Genre"
+// @Param 		 tag        query []string false "Tag, movies having all of the tags are returned" collectionFormat(multi)
+// @Param 		 year       query int    false "Release year"
+// @Param 		 sort       query string false "Sort by" Enums(score, added, name, year, rank)
+// @Param 		 order      query string false "Sort order" Enums(asc, desc)
+// @Param 		 cursor     query string false "Cursor of the page"
+// @Param 		 limit      query int    false "Page size" default(100) maximum(500)
+// @Success      200      {object}  model.PublicList
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /u/{username}/lists/{slug} [get]
+func (h *profileHandler) getPublicList(w http.ResponseWriter, r *http.Request) {
+	filter, err := listFilterFromQuery(r.URL.Query(), &model.ListInfo{})
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	vars := mux.Vars(r)
+	list, err := h.service.GetPublicList(vars["username"], vars["slug"], filter)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, list)
+}
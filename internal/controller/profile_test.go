@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_getPublicList(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockProfileService, filter *model.ListFilter)
+	type testCase struct {
+		name                 string
+		target               string
+		inputFilter          model.ListFilter
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	createdOn := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []testCase{
+		{
+			name:        "OK",
+			target:      "/u/kiryu/lists/7-horror-marathon?status=completed",
+			inputFilter: model.ListFilter{Status: "completed"},
+			mockBehavior: func(s *mock_service.MockProfileService, filter *model.ListFilter) {
+				s.EXPECT().GetPublicList("kiryu", "7-horror-marathon", filter).Return(&model.PublicList{
+					List: &model.List{
+						ID:         7,
+						OwnerID:    42,
+						Name:       "Horror marathon",
+						Visibility: model.VisibilityPublic,
+						Slug:       "7-horror-marathon",
+						CreatedOn:  createdOn,
+					},
+					ListPage: model.ListPage{Movies: []*model.ListUnit{}},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"list\":{\"id\":7,\"user_id\":42,\"name\":\"Horror marathon\",\"description\":\"\",\"is_default\":false,\"visibility\":\"public\",\"slug\":\"7-horror-marathon\",\"created_on\":\"2023-07-01T12:00:00Z\"},\"movies\":[]}\n",
+		},
+		{
+			name:   "Private list",
+			target: "/u/kiryu/lists/8",
+			mockBehavior: func(s *mock_service.MockProfileService, filter *model.ListFilter) {
+				s.EXPECT().GetPublicList("kiryu", "8", filter).Return(nil, &model.NotFoundError{Message: "list \"8\" doesn't exist"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"list \\\"8\\\" doesn't exist\"}\n",
+		},
+		{
+			name:                 "Invalid filter",
+			target:               "/u/kiryu/lists/7?limit=many",
+			mockBehavior:         func(s *mock_service.MockProfileService, filter *model.ListFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid limit\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			profile := mock_service.NewMockProfileService(c)
+			tc.mockBehavior(profile, &tc.inputFilter)
+			var (
+				handler = &profileHandler{service: profile}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/u/{username}/lists/{slug}", handler.getPublicList).Methods(http.MethodGet)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, tc.target, nil)
+			)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
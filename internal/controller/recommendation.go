@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/kiryu-dev/mykinolist/internal/service"
+)
+
+type recommendationHandler struct {
+	service service.RecommendationService
+}
+
+// GetRecommendations godoc
+// @Summary      Get recommendations
+// @Security	 AccessToken
+// @Description  Get movies that aren't in the user's lists yet, but are similar to the ones the user has scored high or favorited according to other users' scores. New accounts get movies of the genres they watch, or just popular ones
+// @Tags         recommendations
+// @Produce      json
+// @Param 		 limit query int false "Count of recommendations" default(20) maximum(100)
+// @Success      200      {array}   model.Recommendation
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /recommendations [get]
+func (h *recommendationHandler) getRecommendations(w http.ResponseWriter, r *http.Request) {
+	query := &model.RecommendationQuery{UserID: r.Context().Value(userIDKey{}).(int64)}
+	if values := r.URL.Query(); values.Has("limit") {
+		limit, err := strconv.Atoi(values.Get("limit"))
+		if err != nil {
+			writeErrorJSON(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		query.Limit = limit
+	}
+	recommendations, err := h.service.GetRecommendations(query)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, recommendations)
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_getRecommendations(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockRecommendationService, query *model.RecommendationQuery)
+	type testCase struct {
+		name                 string
+		target               string
+		inputQuery           model.RecommendationQuery
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:       "OK",
+			target:     "/recommendations?limit=2",
+			inputQuery: model.RecommendationQuery{UserID: 42, Limit: 2},
+			mockBehavior: func(s *mock_service.MockRecommendationService, query *model.RecommendationQuery) {
+				s.EXPECT().GetRecommendations(query).Return([]*model.Recommendation{
+					{Movie: model.Movie{ID: 301, Name: "Матрица"}, Relevance: 7.5, Source: model.RecommendationSimilar},
+					{Movie: model.Movie{ID: 326, Name: "Побег из Шоушенка"}, Relevance: 0.4, Source: model.RecommendationGenre},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "[{\"id\":301,\"name\":\"Матрица\",\"relevance\":7.5,\"source\":\"similar\"},{\"id\":326,\"name\":\"Побег из Шоушенка\",\"relevance\":0.4,\"source\":\"genre\"}]\n",
+		},
+		{
+			name:                 "Invalid limit",
+			target:               "/recommendations?limit=all",
+			mockBehavior:         func(s *mock_service.MockRecommendationService, query *model.RecommendationQuery) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid limit\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			recommendation := mock_service.NewMockRecommendationService(c)
+			tc.mockBehavior(recommendation, &tc.inputQuery)
+			var (
+				handler = &recommendationHandler{service: recommendation}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/recommendations", handler.getRecommendations).Methods(http.MethodGet)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -2,7 +2,10 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
 )
 
 type errorResponse struct {
@@ -19,3 +22,21 @@ func writeErrorJSON(w http.ResponseWriter, status int, errMessage string) error
 	errResponse := &errorResponse{Error: errMessage}
 	return writeJSONResponse(w, status, errResponse)
 }
+
+/* Typed service errors have their own status codes, any other error gets the default one */
+func errorStatusCode(err error, defaultStatus int) int {
+	var (
+		conflictErr           *model.ConflictError
+		notFoundErr           *model.NotFoundError
+		preconditionFailedErr *model.PreconditionFailedError
+	)
+	switch {
+	case errors.As(err, &conflictErr):
+		return http.StatusConflict
+	case errors.As(err, &notFoundErr):
+		return http.StatusNotFound
+	case errors.As(err, &preconditionFailedErr):
+		return http.StatusPreconditionFailed
+	}
+	return defaultStatus
+}
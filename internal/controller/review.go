@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/kiryu-dev/mykinolist/internal/service"
+)
+
+type reviewHandler struct {
+	service service.ReviewService
+}
+
+// AddReview godoc
+// @Summary      Add review
+// @Security	 AccessToken
+// @Description  Write a review of the movie in markdown, one review per movie. The review is private unless is_public is set, has_spoilers warns other users that it reveals the plot
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Param 		 input body model.Review true "review"
+// @Success      201      {object}  model.Review
+// @Failure      400,404  {object}  errorResponse
+// @Failure      409      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/review [post]
+func (h *reviewHandler) addReview(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.Review)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.MovieID = movie.ID
+	req.ListInfo = movie.ListInfo
+	if err := h.service.AddReview(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusCreated, req)
+}
+
+// GetReview godoc
+// @Summary      Get review
+// @Security	 AccessToken
+// @Description  Get the review of the movie with its body rendered to sanitized HTML
+// @Tags         reviews
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Success      200      {object}  model.Review
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/review [get]
+func (h *reviewHandler) getReview(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	review := &model.Review{MovieID: movie.ID, ListInfo: movie.ListInfo}
+	if err := h.service.GetReview(review); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, review)
+}
+
+// UpdateReview godoc
+// @Summary      Update review
+// @Security	 AccessToken
+// @Description  Edit the review body, or change whether it's public or has spoilers
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Param 		 input body model.ReviewPatch true "review fields to update"
+// @Success      200      {object}  model.Review
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/review [patch]
+func (h *reviewHandler) updateReview(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.ReviewPatch)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.MovieID = movie.ID
+	req.ListInfo = movie.ListInfo
+	review, err := h.service.UpdateReview(req)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, review)
+}
+
+// DeleteReview godoc
+// @Summary      Delete review
+// @Security	 AccessToken
+// @Description  Delete the review of the movie
+// @Tags         reviews
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/review [delete]
+func (h *reviewHandler) deleteReview(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := &model.Review{MovieID: movie.ID, ListInfo: movie.ListInfo}
+	if err := h.service.DeleteReview(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("review has been deleted"))
+}
+
+// GetPublicReviews godoc
+// @Summary      Get public reviews
+// @Security	 AccessToken
+// @Description  Get public reviews of the movie written by all users, the latest go first. Reviews of users with private profiles aren't shown
+// @Tags         reviews
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Success      200      {array}   model.Review
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /movies/{id}/reviews [get]
+func (h *reviewHandler) getPublicReviews(w http.ResponseWriter, r *http.Request) {
+	movieID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reviews, err := h.service.GetPublicReviews(movieID)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, reviews)
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_addReview(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockReviewService, review *model.Review)
+	type testCase struct {
+		name                 string
+		target               string
+		inputBody            string
+		inputReview          model.Review
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:      "OK",
+			target:    "/list/409424/review",
+			inputBody: `{"body":"**Stunning**","is_public":true}`,
+			inputReview: model.Review{
+				MovieID:  409424,
+				Body:     "**Stunning**",
+				IsPublic: true,
+				ListInfo: model.ListInfo{OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockReviewService, review *model.Review) {
+				s.EXPECT().AddReview(review).DoAndReturn(func(r *model.Review) error {
+					r.HTML = "<p><strong>Stunning</strong></p>\n"
+					r.CreatedAt = time.Date(2023, time.July, 2, 10, 0, 0, 0, time.UTC)
+					return nil
+				})
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"movie_id\":409424,\"body\":\"**Stunning**\",\"html\":\"\\u003cp\\u003e\\u003cstrong\\u003eStunning\\u003c/strong\\u003e\\u003c/p\\u003e\\n\",\"has_spoilers\":false,\"is_public\":true,\"created_at\":\"2023-07-02T10:00:00Z\"}\n",
+		},
+		{
+			name:      "Already reviewed",
+			target:    "/lists/7/movies/409424/review",
+			inputBody: `{"body":"once again","has_spoilers":true}`,
+			inputReview: model.Review{
+				MovieID:     409424,
+				Body:        "once again",
+				HasSpoilers: true,
+				ListInfo:    model.ListInfo{ListID: 7, OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockReviewService, review *model.Review) {
+				s.EXPECT().AddReview(review).Return(&model.ConflictError{Message: "movie 409424 has already been reviewed"})
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"error\":\"movie 409424 has already been reviewed\"}\n",
+		},
+		{
+			name:                 "Invalid body",
+			target:               "/list/409424/review",
+			inputBody:            `{"body":`,
+			mockBehavior:         func(s *mock_service.MockReviewService, review *model.Review) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"unexpected EOF\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			review := mock_service.NewMockReviewService(c)
+			tc.mockBehavior(review, &tc.inputReview)
+			var (
+				routes = &movieRoutes{review: &reviewHandler{service: review}}
+				router = mux.NewRouter()
+			)
+			routes.register(router.PathPrefix("/list").Subrouter())
+			routes.register(router.PathPrefix("/lists/{listID:[0-9]+}/movies").Subrouter())
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, tc.target, bytes.NewBufferString(tc.inputBody))
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -10,15 +10,44 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
-func New(auth service.AuthService, list service.ListService) *mux.Router {
+/* routes of a single list, they are served both as /list and /lists/{listID}/movies */
+type movieRoutes struct {
+	list    *listHandler
+	viewing *viewingHandler
+	review  *reviewHandler
+	tag     *tagHandler
+}
+
+func New(services *service.Service) *mux.Router {
 	var (
-		router      = mux.NewRouter()
-		authHandler = &authHandler{service: auth}
-		listHandler = &listHandler{service: list}
-		middleware  = &authMiddleware{service: auth}
-		authRouter  = router.PathPrefix("/auth").Subrouter()
-		userRouter  = router.PathPrefix("/user").Subrouter()
-		listRouter  = router.PathPrefix("/list").Subrouter()
+		router                = mux.NewRouter()
+		authHandler           = &authHandler{service: services.AuthService}
+		listHandler           = &listHandler{service: services.ListService}
+		reviewHandler         = &reviewHandler{service: services.ReviewService}
+		tagHandler            = &tagHandler{service: services.TagService}
+		profileHandler        = &profileHandler{service: services.ProfileService}
+		feedHandler           = &feedHandler{service: services.FeedService}
+		recommendationHandler = &recommendationHandler{service: services.RecommendationService}
+		statusHandler         = &statusHandler{service: services.StatusService}
+		notificationHandler   = &notificationHandler{service: services.NotificationService}
+		middleware            = &authMiddleware{service: services.AuthService}
+		movieRoutes           = &movieRoutes{
+			list:    listHandler,
+			viewing: &viewingHandler{service: services.ViewingService},
+			review:  reviewHandler,
+			tag:     tagHandler,
+		}
+		authRouter            = router.PathPrefix("/auth").Subrouter()
+		userRouter            = router.PathPrefix("/user").Subrouter()
+		listRouter            = router.PathPrefix("/list").Subrouter()
+		listsRouter           = router.PathPrefix("/lists").Subrouter()
+		movieRouter           = router.PathPrefix("/movies").Subrouter()
+		tagsRouter            = router.PathPrefix("/tags").Subrouter()
+		profileRouter         = router.PathPrefix("/u").Subrouter()
+		feedRouter            = router.NewRoute().Subrouter()
+		recommendationsRouter = router.PathPrefix("/recommendations").Subrouter()
+		statusesRouter        = router.PathPrefix("/statuses").Subrouter()
+		notificationsRouter   = router.PathPrefix("/notifications").Subrouter()
 	)
 	router.PathPrefix("/documentation/").Handler(httpSwagger.WrapHandler)
 	{
@@ -29,14 +58,94 @@ func New(auth service.AuthService, list service.ListService) *mux.Router {
 	{
 		userRouter.Use(middleware.identifyUser)
 		userRouter.HandleFunc("/{id:[0-9]+}", authHandler.getUser).Methods(http.MethodGet)
+		userRouter.HandleFunc("/{id:[0-9]+}", profileHandler.updateSettings).Methods(http.MethodPatch)
 		userRouter.HandleFunc("/{id:[0-9]+}", authHandler.deleteUser).Methods(http.MethodDelete)
+		userRouter.HandleFunc("/{id:[0-9]+}/stats", listHandler.getStats).Methods(http.MethodGet)
 	}
 	{
 		listRouter.Use(middleware.identifyUser)
-		listRouter.HandleFunc("", listHandler.addMovie).Methods(http.MethodPost)
-		listRouter.HandleFunc("", listHandler.getMovies).Methods(http.MethodGet)
-		listRouter.HandleFunc("/{id:[0-9]+}", listHandler.updateMovie).Methods(http.MethodPatch)
-		listRouter.HandleFunc("/{id:[0-9]+}", listHandler.deleteMovie).Methods(http.MethodDelete)
+		movieRoutes.register(listRouter)
+	}
+	{
+		listsRouter.Use(middleware.identifyUser)
+		listsRouter.HandleFunc("", listHandler.createList).Methods(http.MethodPost)
+		listsRouter.HandleFunc("", listHandler.getLists).Methods(http.MethodGet)
+		listsRouter.HandleFunc("/{listID:[0-9]+}", listHandler.getList).Methods(http.MethodGet)
+		listsRouter.HandleFunc("/{listID:[0-9]+}", listHandler.updateList).Methods(http.MethodPatch)
+		listsRouter.HandleFunc("/{listID:[0-9]+}", listHandler.deleteList).Methods(http.MethodDelete)
+		movieRoutes.register(listsRouter.PathPrefix("/{listID:[0-9]+}/movies").Subrouter())
+	}
+	{
+		movieRouter.Use(middleware.identifyUser)
+		movieRouter.HandleFunc("/search", listHandler.searchMovies).Methods(http.MethodGet)
+		movieRouter.HandleFunc("/{id:[0-9]+}/reviews", reviewHandler.getPublicReviews).Methods(http.MethodGet)
+	}
+	{
+		tagsRouter.Use(middleware.identifyUser)
+		tagsRouter.HandleFunc("", tagHandler.createTag).Methods(http.MethodPost)
+		tagsRouter.HandleFunc("", tagHandler.getTags).Methods(http.MethodGet)
+		tagsRouter.HandleFunc("/{tagID:[0-9]+}", tagHandler.renameTag).Methods(http.MethodPatch)
+		tagsRouter.HandleFunc("/{tagID:[0-9]+}", tagHandler.deleteTag).Methods(http.MethodDelete)
+		tagsRouter.HandleFunc("/{tagID:[0-9]+}/merge", tagHandler.mergeTags).Methods(http.MethodPost)
+	}
+	{
+		profileRouter.HandleFunc("/{username}", profileHandler.getProfile).Methods(http.MethodGet)
+		profileRouter.HandleFunc("/{username}/lists/{slug}", profileHandler.getPublicList).Methods(http.MethodGet)
+	}
+	{
+		feedRouter.Use(middleware.identifyUser)
+		feedRouter.HandleFunc("/feed", feedHandler.getFeed).Methods(http.MethodGet)
+		feedRouter.HandleFunc("/following", feedHandler.getFollowing).Methods(http.MethodGet)
+		feedRouter.HandleFunc("/following/{username}", feedHandler.follow).Methods(http.MethodPut)
+		feedRouter.HandleFunc("/following/{username}", feedHandler.unfollow).Methods(http.MethodDelete)
+		feedRouter.HandleFunc("/followers", feedHandler.getFollowers).Methods(http.MethodGet)
+	}
+	{
+		recommendationsRouter.Use(middleware.identifyUser)
+		recommendationsRouter.HandleFunc("", recommendationHandler.getRecommendations).Methods(http.MethodGet)
+	}
+	{
+		statusesRouter.Use(middleware.identifyUser)
+		statusesRouter.HandleFunc("", statusHandler.createStatus).Methods(http.MethodPost)
+		statusesRouter.HandleFunc("", statusHandler.getStatuses).Methods(http.MethodGet)
+		statusesRouter.HandleFunc("/order", statusHandler.reorderStatuses).Methods(http.MethodPut)
+		statusesRouter.HandleFunc("/{statusID:[0-9]+}", statusHandler.renameStatus).Methods(http.MethodPatch)
+		statusesRouter.HandleFunc("/{statusID:[0-9]+}", statusHandler.removeStatus).Methods(http.MethodDelete)
+	}
+	{
+		notificationsRouter.Use(middleware.identifyUser)
+		notificationsRouter.HandleFunc("", notificationHandler.getNotifications).Methods(http.MethodGet)
+		notificationsRouter.HandleFunc("", notificationHandler.dismissNotifications).Methods(http.MethodDelete)
+		notificationsRouter.HandleFunc("/{id:[0-9]+}", notificationHandler.dismissNotification).Methods(http.MethodDelete)
 	}
 	return router
 }
+
+func (h *movieRoutes) register(router *mux.Router) {
+	router.HandleFunc("", h.list.addMovie).Methods(http.MethodPost)
+	router.HandleFunc("", h.list.getMovies).Methods(http.MethodGet)
+	router.HandleFunc("/import", h.list.importMovies).Methods(http.MethodPost)
+	router.HandleFunc("/import/{id:[0-9]+}", h.list.getImport).Methods(http.MethodGet)
+	router.HandleFunc("/batch", h.list.batchMovies).Methods(http.MethodPost)
+	router.HandleFunc("/order", h.list.reorderMovies).Methods(http.MethodPut)
+	router.HandleFunc("/export", h.list.exportMovies).Methods(http.MethodGet)
+	router.HandleFunc("/trash", h.list.getTrash).Methods(http.MethodGet)
+	router.HandleFunc("/collections", h.list.getCollections).Methods(http.MethodGet)
+	router.HandleFunc("/collections/{id:[0-9]+}/plan", h.list.planCollection).Methods(http.MethodPost)
+	router.HandleFunc("/trash/{id:[0-9]+}/restore", h.list.restoreMovie).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}", h.list.getMovie).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}", h.list.updateMovie).Methods(http.MethodPatch)
+	router.HandleFunc("/{id:[0-9]+}", h.list.deleteMovie).Methods(http.MethodDelete)
+	router.HandleFunc("/{id:[0-9]+}/progress", h.list.incrementProgress).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/move", h.list.moveMovie).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/history", h.list.getHistory).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}/viewings", h.viewing.addViewing).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/viewings", h.viewing.getViewings).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}/viewings/{viewingID:[0-9]+}", h.viewing.deleteViewing).Methods(http.MethodDelete)
+	router.HandleFunc("/{id:[0-9]+}/review", h.review.addReview).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/review", h.review.getReview).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}/review", h.review.updateReview).Methods(http.MethodPatch)
+	router.HandleFunc("/{id:[0-9]+}/review", h.review.deleteReview).Methods(http.MethodDelete)
+	router.HandleFunc("/{id:[0-9]+}/tags/{tagID:[0-9]+}", h.tag.attachTag).Methods(http.MethodPut)
+	router.HandleFunc("/{id:[0-9]+}/tags/{tagID:[0-9]+}", h.tag.detachTag).Methods(http.MethodDelete)
+}
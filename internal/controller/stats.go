@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+// GetStats godoc
+// @Summary      Get user stats
+// @Security	 AccessToken
+// @Description  Get statistics of the default list (or the specified one): counts by status, favorites, mean score and score distribution, breakdowns by genre, decade and country, and total watch time in minutes. Unscored movies don't affect mean scores
+// @Tags         user
+// @Produce      json
+// @Param 		 id      path  int true  "User ID"
+// @Param 		 list_id query int false "List ID, the default list if it's omitted"
+// @Success      200      {object}  model.Stats
+// @Failure      400,404  {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /user/{id}/stats [get]
+func (h *listHandler) getStats(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	idFromCtx := r.Context().Value(userIDKey{}).(int64)
+	if id != idFromCtx {
+		writeErrorJSON(w, http.StatusForbidden, "cannot get other's stats")
+		return
+	}
+	list := &model.ListInfo{OwnerID: id}
+	if values := r.URL.Query(); values.Has("list_id") {
+		if list.ListID, err = strconv.ParseInt(values.Get("list_id"), 10, 64); err != nil {
+			writeErrorJSON(w, http.StatusBadRequest, "invalid list_id")
+			return
+		}
+	}
+	stats, err := h.service.GetStats(list)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, stats)
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_getStats(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListService, list *model.ListInfo)
+	type testCase struct {
+		name                 string
+		target               string
+		inputList            model.ListInfo
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:      "OK",
+			target:    "/user/42/stats",
+			inputList: model.ListInfo{OwnerID: 42},
+			mockBehavior: func(s *mock_service.MockListService, list *model.ListInfo) {
+				s.EXPECT().GetStats(list).Return(&model.Stats{
+					Total:             2,
+					ByStatus:          map[string]int{"completed": 1, "plan to watch": 1},
+					Favorites:         1,
+					MeanScore:         8,
+					ScoreDistribution: map[string]int{"8": 1},
+					Genres:            []*model.StatsBucket{{Name: "фантастика", Count: 2, MeanScore: 8}},
+					Decades:           []*model.StatsBucket{{Name: "2020s", Count: 2, MeanScore: 8}},
+					Countries:         []*model.StatsBucket{{Name: "США", Count: 2, MeanScore: 8}},
+					WatchTime:         155,
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"total\":2,\"by_status\":{\"completed\":1,\"plan to watch\":1},\"favorites\":1,\"mean_score\":8,\"score_distribution\":{\"8\":1},\"genres\":[{\"name\":\"фантастика\",\"count\":2,\"mean_score\":8}],\"decades\":[{\"name\":\"2020s\",\"count\":2,\"mean_score\":8}],\"countries\":[{\"name\":\"США\",\"count\":2,\"mean_score\":8}],\"watch_time\":155}\n",
+		},
+		{
+			name:      "Named list",
+			target:    "/user/42/stats?list_id=7",
+			inputList: model.ListInfo{ListID: 7, OwnerID: 42},
+			mockBehavior: func(s *mock_service.MockListService, list *model.ListInfo) {
+				s.EXPECT().GetStats(list).Return(nil, &model.NotFoundError{Message: "list 7 doesn't exist"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"list 7 doesn't exist\"}\n",
+		},
+		{
+			name:                 "Someone else's stats",
+			target:               "/user/43/stats",
+			mockBehavior:         func(s *mock_service.MockListService, list *model.ListInfo) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"error\":\"cannot get other's stats\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			list := mock_service.NewMockListService(c)
+			tc.mockBehavior(list, &tc.inputList)
+			var (
+				handler = &listHandler{service: list}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/user/{id:[0-9]+}/stats", handler.getStats).Methods(http.MethodGet)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodGet, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/kiryu-dev/mykinolist/internal/service"
+)
+
+type statusHandler struct {
+	service service.StatusService
+}
+
+// CreateStatus godoc
+// @Summary      Create status
+// @Security	 AccessToken
+// @Description  Create a status of movies, e.g. "rewatching" or "waiting for next season". The status goes last, status names are case insensitive
+// @Tags         statuses
+// @Accept       json
+// @Produce      json
+// @Param 		 input body model.Status true "status info"
+// @Success      201      {object}  model.Status
+// @Failure      400,404  {object}  errorResponse
+// @Failure      409      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /statuses [post]
+func (h *statusHandler) createStatus(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	req := new(model.Status)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.OwnerID = userID
+	req.IsBuiltin = false
+	if err := h.service.CreateStatus(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusCreated, req)
+}
+
+// GetStatuses godoc
+// @Summary      Get statuses
+// @Security	 AccessToken
+// @Description  Get all statuses of the user in their order. The built-in ones (watching, completed, on-hold, dropped, plan to watch) can only be reordered
+// @Tags         statuses
+// @Produce      json
+// @Success      200      {array}   model.Status
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /statuses [get]
+func (h *statusHandler) getStatuses(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	statuses, err := h.service.GetStatuses(userID)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, statuses)
+}
+
+// RenameStatus godoc
+// @Summary      Rename status
+// @Security	 AccessToken
+// @Description  Rename the status, movies of the status get the new name. Built-in statuses cannot be renamed
+// @Tags         statuses
+// @Accept       json
+// @Produce      json
+// @Param 		 statusID path int true "Status ID"
+// @Param 		 input body model.Status true "new status name"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      409      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /statuses/{statusID} [patch]
+func (h *statusHandler) renameStatus(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	statusID, err := strconv.ParseInt(mux.Vars(r)["statusID"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.Status)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.ID = statusID
+	req.OwnerID = userID
+	if err := h.service.RenameStatus(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("status has been renamed"))
+}
+
+// ReorderStatuses godoc
+// @Summary      Reorder statuses
+// @Security	 AccessToken
+// @Description  Set the order of the statuses, IDs of all the user's statuses must be specified
+// @Tags         statuses
+// @Accept       json
+// @Produce      json
+// @Param 		 input body model.StatusOrder true "status IDs in the new order"
+// @Success      200      {array}   model.Status
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /statuses/order [put]
+func (h *statusHandler) reorderStatuses(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	req := new(model.StatusOrder)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.OwnerID = userID
+	statuses, err := h.service.ReorderStatuses(req)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, statuses)
+}
+
+// RemoveStatus godoc
+// @Summary      Remove status
+// @Security	 AccessToken
+// @Description  Remove the status, its movies get the status specified by "into". Built-in statuses cannot be removed
+// @Tags         statuses
+// @Produce      json
+// @Param 		 statusID path  int true "Status ID"
+// @Param 		 into     query int true "ID of the status the movies get"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /statuses/{statusID} [delete]
+func (h *statusHandler) removeStatus(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	statusID, err := strconv.ParseInt(mux.Vars(r)["statusID"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := &model.StatusRemoval{ID: statusID, OwnerID: userID}
+	if values := r.URL.Query(); values.Has("into") {
+		if req.TargetID, err = strconv.ParseInt(values.Get("into"), 10, 64); err != nil {
+			writeErrorJSON(w, http.StatusBadRequest, "invalid into")
+			return
+		}
+	}
+	if err := h.service.RemoveStatus(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("status has been removed"))
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_reorderStatuses(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockStatusService, order *model.StatusOrder)
+	type testCase struct {
+		name                 string
+		inputBody            string
+		inputOrder           model.StatusOrder
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:       "OK",
+			inputBody:  `{"ids":[2,1]}`,
+			inputOrder: model.StatusOrder{IDs: []int64{2, 1}, OwnerID: 42},
+			mockBehavior: func(s *mock_service.MockStatusService, order *model.StatusOrder) {
+				s.EXPECT().ReorderStatuses(order).Return([]*model.Status{
+					{ID: 2, Name: "rewatching", Position: 1},
+					{ID: 1, Name: "watching", Position: 2, IsBuiltin: true},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "[{\"id\":2,\"name\":\"rewatching\",\"position\":1,\"is_builtin\":false},{\"id\":1,\"name\":\"watching\",\"position\":2,\"is_builtin\":true}]\n",
+		},
+		{
+			name:       "Not all statuses",
+			inputBody:  `{"ids":[2]}`,
+			inputOrder: model.StatusOrder{IDs: []int64{2}, OwnerID: 42},
+			mockBehavior: func(s *mock_service.MockStatusService, order *model.StatusOrder) {
+				s.EXPECT().ReorderStatuses(order).Return(nil, fmt.Errorf("all 2 statuses must be specified"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"all 2 statuses must be specified\"}\n",
+		},
+		{
+			name:       "Unknown status",
+			inputBody:  `{"ids":[2,7]}`,
+			inputOrder: model.StatusOrder{IDs: []int64{2, 7}, OwnerID: 42},
+			mockBehavior: func(s *mock_service.MockStatusService, order *model.StatusOrder) {
+				s.EXPECT().ReorderStatuses(order).Return(nil, &model.NotFoundError{Message: "status 7 doesn't exist"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"status 7 doesn't exist\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			status := mock_service.NewMockStatusService(c)
+			tc.mockBehavior(status, &tc.inputOrder)
+			var (
+				handler = &statusHandler{service: status}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/statuses/order", handler.reorderStatuses).Methods(http.MethodPut)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPut, "/statuses/order", bytes.NewBufferString(tc.inputBody))
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_removeStatus(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockStatusService, removal *model.StatusRemoval)
+	type testCase struct {
+		name                 string
+		target               string
+		inputRemoval         model.StatusRemoval
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:         "OK",
+			target:       "/statuses/6?into=3",
+			inputRemoval: model.StatusRemoval{ID: 6, TargetID: 3, OwnerID: 42},
+			mockBehavior: func(s *mock_service.MockStatusService, removal *model.StatusRemoval) {
+				s.EXPECT().RemoveStatus(removal).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "status has been removed",
+		},
+		{
+			name:         "Built-in status",
+			target:       "/statuses/1?into=3",
+			inputRemoval: model.StatusRemoval{ID: 1, TargetID: 3, OwnerID: 42},
+			mockBehavior: func(s *mock_service.MockStatusService, removal *model.StatusRemoval) {
+				s.EXPECT().RemoveStatus(removal).Return(fmt.Errorf("status \"watching\" is built-in, it can only be reordered"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"status \\\"watching\\\" is built-in, it can only be reordered\"}\n",
+		},
+		{
+			name:                 "Invalid target",
+			target:               "/statuses/6?into=completed",
+			mockBehavior:         func(s *mock_service.MockStatusService, removal *model.StatusRemoval) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"invalid into\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			status := mock_service.NewMockStatusService(c)
+			tc.mockBehavior(status, &tc.inputRemoval)
+			var (
+				handler = &statusHandler{service: status}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/statuses/{statusID:[0-9]+}", handler.removeStatus).Methods(http.MethodDelete)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodDelete, tc.target, nil)
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/kiryu-dev/mykinolist/internal/service"
+)
+
+type tagHandler struct {
+	service service.TagService
+}
+
+// CreateTag godoc
+// @Summary      Create tag
+// @Security	 AccessToken
+// @Description  Create a tag to label movies with, e.g. "rewatch-worthy" or "cinema". Tag names are case insensitive
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param 		 input body model.Tag true "tag info"
+// @Success      201      {object}  model.Tag
+// @Failure      400,404  {object}  errorResponse
+// @Failure      409      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /tags [post]
+func (h *tagHandler) createTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	req := new(model.Tag)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.OwnerID = userID
+	if err := h.service.CreateTag(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusCreated, req)
+}
+
+// GetTags godoc
+// @Summary      Get tags
+// @Security	 AccessToken
+// @Description  Get all tags of the user with counts of tagged movies
+// @Tags         tags
+// @Produce      json
+// @Success      200      {array}   model.Tag
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /tags [get]
+func (h *tagHandler) getTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	tags, err := h.service.GetTags(userID)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, tags)
+}
+
+// RenameTag godoc
+// @Summary      Rename tag
+// @Security	 AccessToken
+// @Description  Rename the tag. If there's already a tag with the new name, merge the tags instead
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param 		 tagID path int true "Tag ID"
+// @Param 		 input body model.Tag true "new tag name"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      409      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /tags/{tagID} [patch]
+func (h *tagHandler) renameTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	tagID, err := strconv.ParseInt(mux.Vars(r)["tagID"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.Tag)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.ID = tagID
+	req.OwnerID = userID
+	if err := h.service.RenameTag(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("tag has been renamed"))
+}
+
+// MergeTags godoc
+// @Summary      Merge tags
+// @Security	 AccessToken
+// @Description  Label all movies of the tag with the target tag ("into") and delete the tag
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param 		 tagID path int true "Tag ID"
+// @Param 		 input body model.TagMerge true "target tag"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /tags/{tagID}/merge [post]
+func (h *tagHandler) mergeTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	tagID, err := strconv.ParseInt(mux.Vars(r)["tagID"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.TagMerge)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.SourceID = tagID
+	req.OwnerID = userID
+	if err := h.service.MergeTags(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("tags have been merged"))
+}
+
+// DeleteTag godoc
+// @Summary      Delete tag
+// @Security	 AccessToken
+// @Description  Delete the tag, the tagged movies stay in the lists
+// @Tags         tags
+// @Produce      json
+// @Param 		 tagID path int true "Tag ID"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /tags/{tagID} [delete]
+func (h *tagHandler) deleteTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey{}).(int64)
+	tagID, err := strconv.ParseInt(mux.Vars(r)["tagID"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DeleteTag(tagID, userID); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("tag has been deleted"))
+}
+
+// AttachTag godoc
+// @Summary      Tag movie
+// @Security	 AccessToken
+// @Description  Label the movie of the list with the tag
+// @Tags         tags
+// @Produce      json
+// @Param 		 id    path int true "Movie ID"
+// @Param 		 tagID path int true "Tag ID"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/tags/{tagID} [put]
+func (h *tagHandler) attachTag(w http.ResponseWriter, r *http.Request) {
+	movie, tagID, err := taggedMovieFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.AttachTag(movie, tagID); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("tag has been attached"))
+}
+
+// DetachTag godoc
+// @Summary      Untag movie
+// @Security	 AccessToken
+// @Description  Remove the tag from the movie of the list
+// @Tags         tags
+// @Produce      json
+// @Param 		 id    path int true "Movie ID"
+// @Param 		 tagID path int true "Tag ID"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/tags/{tagID} [delete]
+func (h *tagHandler) detachTag(w http.ResponseWriter, r *http.Request) {
+	movie, tagID, err := taggedMovieFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.DetachTag(movie, tagID); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("tag has been detached"))
+}
+
+func taggedMovieFromRequest(r *http.Request) (*model.ListUnit, int64, error) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	tagID, err := strconv.ParseInt(mux.Vars(r)["tagID"], 10, 64)
+	if err != nil {
+		return nil, 0, err
+	}
+	return movie, tagID, nil
+}
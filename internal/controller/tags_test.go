@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_mergeTags(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTagService, merge *model.TagMerge)
+	type testCase struct {
+		name                 string
+		target               string
+		inputBody            string
+		inputMerge           model.TagMerge
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	testCases := []testCase{
+		{
+			name:       "OK",
+			target:     "/tags/3/merge",
+			inputBody:  `{"into":5}`,
+			inputMerge: model.TagMerge{SourceID: 3, TargetID: 5, OwnerID: 42},
+			mockBehavior: func(s *mock_service.MockTagService, merge *model.TagMerge) {
+				s.EXPECT().MergeTags(merge).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "tags have been merged",
+		},
+		{
+			name:       "Unknown target",
+			target:     "/tags/3/merge",
+			inputBody:  `{"into":8}`,
+			inputMerge: model.TagMerge{SourceID: 3, TargetID: 8, OwnerID: 42},
+			mockBehavior: func(s *mock_service.MockTagService, merge *model.TagMerge) {
+				s.EXPECT().MergeTags(merge).Return(&model.NotFoundError{Message: "tag 8 doesn't exist"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"tag 8 doesn't exist\"}\n",
+		},
+		{
+			name:                 "Invalid body",
+			target:               "/tags/3/merge",
+			inputBody:            `{"into":"cinema"}`,
+			mockBehavior:         func(s *mock_service.MockTagService, merge *model.TagMerge) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"error\":\"json: cannot unmarshal string into Go struct field TagMerge.into of type int64\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			tag := mock_service.NewMockTagService(c)
+			tc.mockBehavior(tag, &tc.inputMerge)
+			var (
+				handler = &tagHandler{service: tag}
+				router  = mux.NewRouter()
+			)
+			router.HandleFunc("/tags/{tagID:[0-9]+}/merge", handler.mergeTags).Methods(http.MethodPost)
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, tc.target, bytes.NewBufferString(tc.inputBody))
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
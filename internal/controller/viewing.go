@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/kiryu-dev/mykinolist/internal/service"
+)
+
+type viewingHandler struct {
+	service service.ViewingService
+}
+
+// AddViewing godoc
+// @Summary      Add viewing
+// @Security	 AccessToken
+// @Description  Record the viewing of the movie to the diary: watch date (today by default), score of this viewing, whether it's a rewatch, and a note. The movie status becomes "completed"
+// @Tags         diary
+// @Accept       json
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Param 		 input body model.Viewing true "viewing info"
+// @Success      201      {object}  model.Viewing
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/viewings [post]
+func (h *viewingHandler) addViewing(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := new(model.Viewing)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.Body.Close()
+	req.MovieID = movie.ID
+	req.ListInfo = movie.ListInfo
+	if err := h.service.AddViewing(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusCreated, req)
+}
+
+// GetViewings godoc
+// @Summary      Get viewings
+// @Security	 AccessToken
+// @Description  Get the diary of the movie, the latest viewings go first
+// @Tags         diary
+// @Produce      json
+// @Param 		 id path int true "Movie ID"
+// @Success      200      {array}   model.Viewing
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/viewings [get]
+func (h *viewingHandler) getViewings(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	viewings, err := h.service.GetViewings(movie)
+	if err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, viewings)
+}
+
+// DeleteViewing godoc
+// @Summary      Delete viewing
+// @Security	 AccessToken
+// @Description  Delete the viewing from the diary, the movie status stays the same
+// @Tags         diary
+// @Produce      json
+// @Param 		 id        path int true "Movie ID"
+// @Param 		 viewingID path int true "Viewing ID"
+// @Success      200      {string}  string
+// @Failure      400,404  {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Failure      default  {object}  errorResponse
+// @Router       /list/{id}/viewings/{viewingID} [delete]
+func (h *viewingHandler) deleteViewing(w http.ResponseWriter, r *http.Request) {
+	movie, err := listUnitFromRequest(r)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	viewingID, err := strconv.ParseInt(mux.Vars(r)["viewingID"], 10, 64)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req := &model.Viewing{ID: viewingID, MovieID: movie.ID, ListInfo: movie.ListInfo}
+	if err := h.service.DeleteViewing(req); err != nil {
+		writeErrorJSON(w, errorStatusCode(err, http.StatusBadRequest), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("viewing has been deleted"))
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	mock_service "github.com/kiryu-dev/mykinolist/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController_addViewing(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockViewingService, viewing *model.Viewing)
+	type testCase struct {
+		name                 string
+		target               string
+		inputBody            string
+		inputViewing         model.Viewing
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}
+	score := 9.0
+	testCases := []testCase{
+		{
+			name:      "OK",
+			target:    "/list/409424/viewings",
+			inputBody: `{"watched_on":"2023-07-01","score":9,"is_rewatch":true,"note":"still great"}`,
+			inputViewing: model.Viewing{
+				MovieID:   409424,
+				WatchedOn: "2023-07-01",
+				Score:     &score,
+				IsRewatch: true,
+				Note:      "still great",
+				ListInfo:  model.ListInfo{OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockViewingService, viewing *model.Viewing) {
+				s.EXPECT().AddViewing(viewing).DoAndReturn(func(v *model.Viewing) error {
+					v.ID = 1
+					v.CreatedAt = time.Date(2023, time.July, 2, 10, 0, 0, 0, time.UTC)
+					return nil
+				})
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":1,\"movie_id\":409424,\"watched_on\":\"2023-07-01\",\"score\":9,\"is_rewatch\":true,\"note\":\"still great\",\"created_at\":\"2023-07-02T10:00:00Z\"}\n",
+		},
+		{
+			name:      "Movie isn't in the list",
+			target:    "/lists/7/movies/5029/viewings",
+			inputBody: `{"watched_on":"2023-07-01"}`,
+			inputViewing: model.Viewing{
+				MovieID:   5029,
+				WatchedOn: "2023-07-01",
+				ListInfo:  model.ListInfo{ListID: 7, OwnerID: 42},
+			},
+			mockBehavior: func(s *mock_service.MockViewingService, viewing *model.Viewing) {
+				s.EXPECT().AddViewing(viewing).Return(&model.NotFoundError{Message: "movie 5029 isn't in the list"})
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"error\":\"movie 5029 isn't in the list\"}\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			viewing := mock_service.NewMockViewingService(c)
+			tc.mockBehavior(viewing, &tc.inputViewing)
+			var (
+				routes = &movieRoutes{viewing: &viewingHandler{service: viewing}}
+				router = mux.NewRouter()
+			)
+			routes.register(router.PathPrefix("/list").Subrouter())
+			routes.register(router.PathPrefix("/lists/{listID:[0-9]+}/movies").Subrouter())
+			var (
+				w   = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, tc.target, bytes.NewBufferString(tc.inputBody))
+			)
+			req = req.WithContext(context.WithValue(req.Context(), userIDKey{}, int64(42)))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
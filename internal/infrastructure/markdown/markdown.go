@@ -0,0 +1,30 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+}
+
+func New() *Renderer {
+	return &Renderer{
+		markdown: goldmark.New(goldmark.WithExtensions(extension.Strikethrough, extension.Linkify)),
+		policy:   bluemonday.UGCPolicy(),
+	}
+}
+
+/* Render the user's markdown to HTML, that is safe to be embedded into a page */
+func (r *Renderer) Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := r.markdown.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return r.policy.Sanitize(buf.String()), nil
+}
@@ -0,0 +1,39 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "Markdown",
+			source:   "**Great** ~~film~~ series",
+			expected: "<p><strong>Great</strong> <del>film</del> series</p>\n",
+		},
+		{
+			name:     "Raw HTML",
+			source:   "<script>alert(1)</script>\n\n<b onclick=\"alert(1)\">bold</b>",
+			expected: "\n<p>bold</p>\n",
+		},
+		{
+			name:     "Unsafe link",
+			source:   "[trailer](javascript:alert(1))",
+			expected: "<p>trailer</p>\n",
+		},
+	}
+	renderer := New()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			html, err := renderer.Render(tc.source)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, html)
+		})
+	}
+}
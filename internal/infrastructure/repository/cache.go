@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/lib/pq"
+)
+
+type movieCacheRepository struct {
+	db *sql.DB
+}
+
+/* movies are LEFT JOINed to list titles, so every column is coalesced to the zero value, unknown dates are nil */
+const movieColumns = `
+COALESCE(movies.name, ''), COALESCE(movies.alternative_name, ''), COALESCE(movies.en_name, ''),
+COALESCE(movies.type, ''), COALESCE(movies.year, 0), COALESCE(movies.genres, '{}'),
+COALESCE(movies.countries, '{}'), COALESCE(movies.duration, 0), COALESCE(movies.age_rating, 0),
+COALESCE(movies.poster_url, ''), COALESCE(movies.kp_rating, 0), COALESCE(movies.imdb_rating, 0),
+COALESCE(movies.is_series, FALSE), COALESCE(movies.episodes_per_season, '{}'),
+COALESCE(movies.imdb_id, ''), movies.premiere, movies.digital_release`
+
+/* scan destinations for movieColumns */
+func movieFields(movie *model.Movie) []any {
+	return []any{
+		&movie.Name, &movie.AlternativeName, &movie.EnName,
+		&movie.Type, &movie.Year, pq.Array(&movie.Genres),
+		pq.Array(&movie.Countries), &movie.Duration, &movie.AgeRating,
+		&movie.PosterURL, &movie.KinopoiskRating, &movie.IMDbRating,
+		&movie.IsSeries, pq.Array(&movie.EpisodesPerSeason),
+		&movie.IMDbID, &movie.Premiere, &movie.DigitalRelease,
+	}
+}
+
+/* Save the movie with its sequels and prequels, the ones saved before are replaced */
+func (r *movieCacheRepository) Save(ctx context.Context, movie *model.Movie) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := `
+INSERT INTO movies (id, name, alternative_name, en_name, type, year, genres, countries,
+	duration, age_rating, poster_url, kp_rating, imdb_rating, is_series,
+	episodes_per_season, imdb_id, premiere, digital_release, updated_on)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, ''), $17, $18, $19)
+ON CONFLICT (id) DO UPDATE
+SET name = EXCLUDED.name, alternative_name = EXCLUDED.alternative_name,
+	en_name = EXCLUDED.en_name, type = EXCLUDED.type, year = EXCLUDED.year,
+	genres = EXCLUDED.genres, countries = EXCLUDED.countries,
+	duration = EXCLUDED.duration, age_rating = EXCLUDED.age_rating,
+	poster_url = EXCLUDED.poster_url, kp_rating = EXCLUDED.kp_rating,
+	imdb_rating = EXCLUDED.imdb_rating, is_series = EXCLUDED.is_series,
+	episodes_per_season = EXCLUDED.episodes_per_season, imdb_id = EXCLUDED.imdb_id,
+	premiere = EXCLUDED.premiere, digital_release = EXCLUDED.digital_release,
+	updated_on = EXCLUDED.updated_on;
+	`
+	_, err = tx.ExecContext(ctx, query, movie.ID, movie.Name, movie.AlternativeName,
+		movie.EnName, movie.Type, movie.Year, pq.Array(movie.Genres), pq.Array(movie.Countries),
+		movie.Duration, movie.AgeRating, movie.PosterURL, movie.KinopoiskRating,
+		movie.IMDbRating, movie.IsSeries, pq.Array(movie.EpisodesPerSeason), movie.IMDbID,
+		movie.Premiere, movie.DigitalRelease, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := saveSequels(ctx, tx, movie); err != nil {
+		return err
+	}
+	query = `DELETE FROM movie_refresh_failures WHERE movie_id = $1;`
+	if _, err := tx.ExecContext(ctx, query, movie.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+/* Record the failed fetch of the movie, it's retried after the movies that haven't failed */
+func (r *movieCacheRepository) MarkFailed(ctx context.Context, movieID int64) error {
+	query := `
+INSERT INTO movie_refresh_failures (movie_id) VALUES ($1)
+ON CONFLICT (movie_id) DO UPDATE
+SET attempts = movie_refresh_failures.attempts + 1, failed_at = NOW();
+	`
+	_, err := r.db.ExecContext(ctx, query, movieID)
+	return err
+}
+
+func saveSequels(ctx context.Context, tx *sql.Tx, movie *model.Movie) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM movie_sequels WHERE movie_id = $1;`, movie.ID); err != nil {
+		return err
+	}
+	if len(movie.Sequels) == 0 {
+		return nil
+	}
+	var (
+		ids     = make([]int64, 0, len(movie.Sequels))
+		names   = make([]string, 0, len(movie.Sequels))
+		enNames = make([]string, 0, len(movie.Sequels))
+		types   = make([]string, 0, len(movie.Sequels))
+		posters = make([]string, 0, len(movie.Sequels))
+		years   = make([]int64, 0, len(movie.Sequels))
+	)
+	for _, sequel := range movie.Sequels {
+		ids = append(ids, sequel.ID)
+		names = append(names, sequel.Name)
+		enNames = append(enNames, sequel.EnName)
+		types = append(types, sequel.Type)
+		posters = append(posters, sequel.PosterURL)
+		years = append(years, int64(sequel.Year))
+	}
+	query := `
+INSERT INTO movie_sequels (movie_id, sequel_id, name, en_name, type, poster_url, year)
+SELECT $1, sequels.id, sequels.name, NULLIF(sequels.en_name, ''),
+	NULLIF(sequels.type, ''), NULLIF(sequels.poster_url, ''), NULLIF(sequels.year, 0)
+FROM unnest($2::integer[], $3::text[], $4::text[], $5::text[], $6::text[], $7::smallint[])
+	AS sequels (id, name, en_name, type, poster_url, year)
+WHERE sequels.id <> $1
+ON CONFLICT DO NOTHING;
+	`
+	_, err := tx.ExecContext(ctx, query, movie.ID, pq.Array(ids), pq.Array(names),
+		pq.Array(enNames), pq.Array(types), pq.Array(posters), pq.Array(years))
+	return err
+}
+
+func (r *movieCacheRepository) FindByIMDbID(ctx context.Context, imdbID string) (*model.Movie, error) {
+	query := `SELECT movies.id, ` + movieColumns + ` FROM movies WHERE imdb_id = $1 LIMIT 1;`
+	movie := new(model.Movie)
+	dest := append([]any{&movie.ID}, movieFields(movie)...)
+	err := r.db.QueryRowContext(ctx, query, imdbID).Scan(dest...)
+	if err == sql.ErrNoRows {
+		return nil, &model.NotFoundError{Message: fmt.Sprintf("movie %s isn't cached", imdbID)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return movie, nil
+}
+
+/*
+IDs of listed movies that have never been cached or were cached before the given time, the oldest
+first. The movies that failed to be fetched go last, the least recently failed first
+*/
+func (r *movieCacheRepository) GetOutdated(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	query := `
+SELECT titles.title_id
+FROM (SELECT DISTINCT title_id FROM list_titles) AS titles
+LEFT JOIN movies ON movies.id = titles.title_id
+LEFT JOIN movie_refresh_failures AS failures ON failures.movie_id = titles.title_id
+WHERE movies.id IS NULL OR movies.updated_on < $1
+ORDER BY failures.failed_at NULLS FIRST, movies.updated_on NULLS FIRST, titles.title_id
+LIMIT $2;
+	`
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+/*
+IDs of movies planned to watch that haven't been released (or whose release dates
+are unknown yet) and were cached before the given time. Old movies without the dates
+are skipped, Kinopoisk isn't going to find them out anyway. Ordered like GetOutdated
+*/
+func (r *movieCacheRepository) GetUnreleased(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	query := `
+SELECT titles.title_id
+FROM (
+	SELECT DISTINCT title_id FROM list_titles
+	WHERE status_name = 'plan to watch' AND deleted_at IS NULL
+) AS titles
+LEFT JOIN movies ON movies.id = titles.title_id
+LEFT JOIN movie_refresh_failures AS failures ON failures.movie_id = titles.title_id
+WHERE movies.id IS NULL OR movies.updated_on < $1 AND (
+	movies.premiere > $2::date OR movies.digital_release > $2::date
+	OR movies.digital_release IS NULL AND COALESCE(NULLIF(movies.year, 0), $3) >= $3)
+ORDER BY failures.failed_at NULLS FIRST, movies.updated_on NULLS FIRST, titles.title_id
+LIMIT $4;
+	`
+	now := time.Now()
+	rows, err := r.db.QueryContext(ctx, query, before, now, now.Year()-1, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
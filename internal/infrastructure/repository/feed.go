@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type followRepository struct {
+	db *sql.DB
+}
+
+type activityRepository struct {
+	db *sql.DB
+}
+
+func (r *followRepository) Follow(ctx context.Context, followerID, followeeID int64) error {
+	query := `
+INSERT INTO follows (follower_id, followee_id) VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+	`
+	_, err := r.db.ExecContext(ctx, query, followerID, followeeID)
+	return err
+}
+
+func (r *followRepository) Unfollow(ctx context.Context, followerID, followeeID int64) error {
+	query := `DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2;`
+	res, err := r.db.ExecContext(ctx, query, followerID, followeeID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: "you don't follow the user"}
+	}
+	return nil
+}
+
+func (r *followRepository) GetFollowing(ctx context.Context, userID int64) ([]*model.FollowedUser, error) {
+	query := `
+SELECT users.username, follows.created_on
+FROM follows JOIN users ON users.id = follows.followee_id
+WHERE follows.follower_id = $1
+ORDER BY follows.created_on DESC;
+	`
+	return r.getUsers(ctx, query, userID)
+}
+
+func (r *followRepository) GetFollowers(ctx context.Context, userID int64) ([]*model.FollowedUser, error) {
+	query := `
+SELECT users.username, follows.created_on
+FROM follows JOIN users ON users.id = follows.follower_id
+WHERE follows.followee_id = $1
+ORDER BY follows.created_on DESC;
+	`
+	return r.getUsers(ctx, query, userID)
+}
+
+func (r *followRepository) getUsers(ctx context.Context, query string, userID int64) ([]*model.FollowedUser, error) {
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	users := make([]*model.FollowedUser, 0)
+	for rows.Next() {
+		user := new(model.FollowedUser)
+		if err := rows.Scan(&user.Username, &user.Since); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *activityRepository) Add(ctx context.Context, activity *model.Activity) error {
+	query := `
+INSERT INTO activities (user_id, list_id, title_id, kind, score)
+VALUES ($1, $2, $3, $4, NULLIF($5, 0)) RETURNING id, created_at;
+	`
+	err := r.db.QueryRowContext(ctx, query, activity.UserID, activity.ListID,
+		activity.Movie.ID, activity.Kind, activity.RawScore).Scan(&activity.ID, &activity.CreatedAt)
+	return err
+}
+
+/*
+Get a page of the events of the followed users, newest first. Privacy settings are
+checked on reading, so the events disappear as soon as the list or the review is hidden
+*/
+func (r *activityRepository) GetFeed(ctx context.Context, feed *model.FeedQuery) ([]*model.Activity, string, error) {
+	var (
+		args       = new(queryArgs)
+		conditions = []string{
+			"follows.follower_id = " + args.add(feed.UserID),
+			"users.visibility <> 'private'",
+			"lists.visibility = 'public'",
+			`(activities.kind <> 'reviewed' OR EXISTS (
+	SELECT 1 FROM reviews
+	WHERE reviews.list_id = activities.list_id AND reviews.title_id = activities.title_id
+		AND reviews.is_public))`,
+		}
+	)
+	if feed.Cursor != "" {
+		values, err := decodeCursor(feed.Cursor, 2)
+		if err != nil {
+			return nil, "", err
+		}
+		conditions = append(conditions, fmt.Sprintf("(activities.created_at, activities.id) < (%s::timestamp, %s::bigint)",
+			args.add(values[0]), args.add(values[1])))
+	}
+	query := fmt.Sprintf(`
+SELECT activities.id, activities.user_id, users.username, activities.kind, activities.title_id,
+	%s, COALESCE(activities.score, 0), lists.id, lists.name, activities.created_at,
+	activities.created_at::text
+FROM activities
+JOIN follows ON follows.followee_id = activities.user_id
+JOIN users ON users.id = activities.user_id
+JOIN lists ON lists.id = activities.list_id
+LEFT JOIN movies ON movies.id = activities.title_id
+WHERE %s
+ORDER BY activities.created_at DESC, activities.id DESC
+LIMIT %s;
+	`, movieColumns, strings.Join(conditions, " AND "), args.add(feed.Limit+1))
+	rows, err := r.db.QueryContext(ctx, query, *args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	var (
+		activities = make([]*model.Activity, 0)
+		createdAt  string
+		nextCursor string
+		listName   string
+	)
+	for rows.Next() {
+		if len(activities) == feed.Limit {
+			/* there's one more row, so the page isn't the last one */
+			last := activities[len(activities)-1]
+			nextCursor = encodeCursor([]string{createdAt, fmt.Sprint(last.ID)})
+			break
+		}
+		activity := new(model.Activity)
+		dest := []any{&activity.ID, &activity.UserID, &activity.Username, &activity.Kind, &activity.Movie.ID}
+		dest = append(dest, movieFields(&activity.Movie)...)
+		dest = append(dest, &activity.RawScore, &activity.ListID, &listName, &activity.CreatedAt, &createdAt)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, "", err
+		}
+		activity.ListSlug = model.ListSlug(activity.ListID, listName)
+		activities = append(activities, activity)
+	}
+	return activities, nextCursor, rows.Err()
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/lib/pq"
+)
+
+type importRepository struct {
+	db *sql.DB
+}
+
+/* Queue the rows of the import, the rows are processed in the background */
+func (r *importRepository) Create(ctx context.Context, imp *model.Import) (*model.ImportProgress, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	query := `
+INSERT INTO imports (list_id, owner_id, format, total, finished_at)
+VALUES ($1, $2, $3, $4, CASE WHEN $4::integer = 0 THEN NOW() END)
+RETURNING id, created_at, finished_at;
+	`
+	progress := &model.ImportProgress{Format: imp.Format, Total: len(imp.Rows)}
+	err = tx.QueryRowContext(ctx, query, imp.ListID, imp.OwnerID, imp.Format, len(imp.Rows)).
+		Scan(&progress.ID, &progress.CreatedAt, &progress.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		lines    = make([]int64, 0, len(imp.Rows))
+		titles   = make([]string, 0, len(imp.Rows))
+		years    = make([]int64, 0, len(imp.Rows))
+		imdbIDs  = make([]string, 0, len(imp.Rows))
+		statuses = make([]string, 0, len(imp.Rows))
+		scores   = make([]int64, 0, len(imp.Rows))
+		errs     = make([]string, 0, len(imp.Rows))
+	)
+	for _, row := range imp.Rows {
+		lines = append(lines, int64(row.Line))
+		titles = append(titles, row.Title)
+		years = append(years, int64(row.Year))
+		imdbIDs = append(imdbIDs, row.IMDbID)
+		statuses = append(statuses, row.Status)
+		scores = append(scores, int64(row.RawScore))
+		errs = append(errs, row.Error)
+	}
+	query = `
+INSERT INTO import_rows (import_id, line, title, year, imdb_id, status_name, score, error)
+SELECT $1, imported.line, imported.title, imported.year, NULLIF(imported.imdb_id, ''),
+	imported.status_name, imported.score, NULLIF(imported.error, '')
+FROM unnest($2::integer[], $3::text[], $4::integer[], $5::text[], $6::text[], $7::smallint[], $8::text[])
+	AS imported (line, title, year, imdb_id, status_name, score, error);
+	`
+	_, err = tx.ExecContext(ctx, query, progress.ID, pq.Array(lines), pq.Array(titles), pq.Array(years),
+		pq.Array(imdbIDs), pq.Array(statuses), pq.Array(scores), pq.Array(errs))
+	if err != nil {
+		return nil, err
+	}
+	return progress, tx.Commit()
+}
+
+/* The import of the user with the rows that have been processed so far, in order of the file */
+func (r *importRepository) GetByID(ctx context.Context, id, ownerID int64) (*model.ImportProgress, error) {
+	query := `
+SELECT id, format, total, processed, created_at, finished_at
+FROM imports
+WHERE id = $1 AND owner_id = $2;
+	`
+	progress := new(model.ImportProgress)
+	err := r.db.QueryRowContext(ctx, query, id, ownerID).Scan(&progress.ID, &progress.Format,
+		&progress.Total, &progress.Processed, &progress.CreatedAt, &progress.FinishedAt)
+	if err == sql.ErrNoRows {
+		return nil, &model.NotFoundError{Message: fmt.Sprintf("import %d doesn't exist", id)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	query = `
+SELECT line, title, year, COALESCE(imdb_id, ''), status_name, score, COALESCE(error, ''),
+	movie, candidates
+FROM import_rows
+WHERE import_id = $1 AND processed_at IS NOT NULL
+ORDER BY line;
+	`
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	progress.Rows = make([]*model.ImportRow, 0)
+	for rows.Next() {
+		var (
+			row               = new(model.ImportRow)
+			movie, candidates []byte
+		)
+		err := rows.Scan(&row.Line, &row.Title, &row.Year, &row.IMDbID, &row.Status,
+			&row.RawScore, &row.Error, &movie, &candidates)
+		if err != nil {
+			return nil, err
+		}
+		if movie != nil {
+			if err := json.Unmarshal(movie, &row.Movie); err != nil {
+				return nil, err
+			}
+		}
+		if candidates != nil {
+			if err := json.Unmarshal(candidates, &row.Candidates); err != nil {
+				return nil, err
+			}
+		}
+		progress.Rows = append(progress.Rows, row)
+	}
+	return progress, rows.Err()
+}
+
+/* Up to limit rows that haven't been processed yet, grouped by import, the oldest imports first */
+func (r *importRepository) GetPending(ctx context.Context, limit int) ([]*model.Import, error) {
+	query := `
+SELECT imports.id, imports.list_id, imports.owner_id, imports.format,
+	import_rows.line, import_rows.title, import_rows.year, COALESCE(import_rows.imdb_id, ''),
+	import_rows.status_name, import_rows.score, COALESCE(import_rows.error, '')
+FROM import_rows JOIN imports ON imports.id = import_rows.import_id
+WHERE import_rows.processed_at IS NULL
+ORDER BY import_rows.import_id, import_rows.line
+LIMIT $1;
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	imports := make([]*model.Import, 0)
+	for rows.Next() {
+		var (
+			imp = new(model.Import)
+			row = new(model.ImportRow)
+		)
+		err := rows.Scan(&imp.ID, &imp.ListID, &imp.OwnerID, &imp.Format, &row.Line, &row.Title,
+			&row.Year, &row.IMDbID, &row.Status, &row.RawScore, &row.Error)
+		if err != nil {
+			return nil, err
+		}
+		if len(imports) == 0 || imports[len(imports)-1].ID != imp.ID {
+			imports = append(imports, imp)
+		}
+		last := imports[len(imports)-1]
+		last.Rows = append(last.Rows, row)
+	}
+	return imports, rows.Err()
+}
+
+/* Save the result of the row and count it as processed, the import is finished with its last row */
+func (r *importRepository) SaveRow(ctx context.Context, importID int64, row *model.ImportRow) error {
+	/* the results are passed as text, as pq sends bytes as bytea */
+	var movie, candidates sql.NullString
+	if row.Movie != nil {
+		b, err := json.Marshal(row.Movie)
+		if err != nil {
+			return err
+		}
+		movie = sql.NullString{String: string(b), Valid: true}
+	}
+	if len(row.Candidates) > 0 {
+		b, err := json.Marshal(row.Candidates)
+		if err != nil {
+			return err
+		}
+		candidates = sql.NullString{String: string(b), Valid: true}
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := `
+UPDATE import_rows
+SET error = NULLIF($3, ''), movie = $4::jsonb, candidates = $5::jsonb, processed_at = NOW()
+WHERE import_id = $1 AND line = $2 AND processed_at IS NULL;
+	`
+	res, err := tx.ExecContext(ctx, query, importID, row.Line, row.Error, movie, candidates)
+	if err != nil {
+		return err
+	}
+	/* the row has been processed meanwhile */
+	if count, err := res.RowsAffected(); err != nil || count == 0 {
+		return err
+	}
+	query = `
+UPDATE imports
+SET processed = processed + 1,
+	finished_at = CASE WHEN processed + 1 = total THEN NOW() END
+WHERE id = $1;
+	`
+	if _, err := tx.ExecContext(ctx, query, importID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
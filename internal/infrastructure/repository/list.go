@@ -3,27 +3,34 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/lib/pq"
 )
 
 type listRepository struct {
 	db *sql.DB
 }
 
-func (r *listRepository) Create(ctx context.Context, ownerID int64) (*model.ListInfo, error) {
-	list := new(model.ListInfo)
-	list.OwnerID = ownerID
-	query := `INSERT INTO lists (owner_id) VALUES ($1) RETURNING id;`
-	err := r.db.QueryRowContext(ctx, query, ownerID).Scan(&list.ListID)
-	if err != nil {
-		return nil, err
+func (r *listRepository) Create(ctx context.Context, list *model.List) error {
+	query := `
+INSERT INTO lists (owner_id, name, description, is_default, visibility)
+VALUES ($1, $2, $3, $4, $5) RETURNING id, created_on;
+	`
+	err := r.db.QueryRowContext(ctx, query, list.OwnerID, list.Name,
+		list.Description, list.IsDefault, list.Visibility).Scan(&list.ID, &list.CreatedOn)
+	list.Slug = model.ListSlug(list.ID, list.Name)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return &model.ConflictError{Message: fmt.Sprintf("list %q already exists", list.Name)}
 	}
-	return list, nil
+	return err
 }
 
-func (r *listRepository) GetID(ctx context.Context, ownerID int64) (int64, error) {
-	query := `SELECT id FROM lists WHERE owner_id = $1;`
+func (r *listRepository) GetDefaultID(ctx context.Context, ownerID int64) (int64, error) {
+	query := `SELECT id FROM lists WHERE owner_id = $1 AND is_default;`
 	var id int64
 	err := r.db.QueryRowContext(ctx, query, ownerID).Scan(&id)
 	if err != nil {
@@ -31,3 +38,90 @@ func (r *listRepository) GetID(ctx context.Context, ownerID int64) (int64, error
 	}
 	return id, nil
 }
+
+func (r *listRepository) GetByID(ctx context.Context, listID, ownerID int64) (*model.List, error) {
+	query := `
+SELECT id, owner_id, name, description, is_default, visibility, created_on
+FROM lists WHERE id = $1 AND owner_id = $2;
+	`
+	list := new(model.List)
+	err := r.db.QueryRowContext(ctx, query, listID, ownerID).Scan(
+		&list.ID, &list.OwnerID, &list.Name, &list.Description,
+		&list.IsDefault, &list.Visibility, &list.CreatedOn,
+	)
+	if err == sql.ErrNoRows {
+		return nil, &model.NotFoundError{Message: fmt.Sprintf("list %d doesn't exist", listID)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	list.Slug = model.ListSlug(list.ID, list.Name)
+	return list, nil
+}
+
+func (r *listRepository) GetAll(ctx context.Context, ownerID int64) ([]*model.List, error) {
+	query := `
+SELECT id, owner_id, name, description, is_default, visibility, created_on
+FROM lists WHERE owner_id = $1
+ORDER BY is_default DESC, created_on;
+	`
+	rows, err := r.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	lists := make([]*model.List, 0)
+	for rows.Next() {
+		list := new(model.List)
+		err := rows.Scan(&list.ID, &list.OwnerID, &list.Name, &list.Description,
+			&list.IsDefault, &list.Visibility, &list.CreatedOn)
+		if err != nil {
+			return nil, err
+		}
+		list.Slug = model.ListSlug(list.ID, list.Name)
+		lists = append(lists, list)
+	}
+	return lists, rows.Err()
+}
+
+func (r *listRepository) Update(ctx context.Context, list *model.ListPatch) error {
+	query := `
+UPDATE lists
+SET name = COALESCE($1, name), description = COALESCE($2, description),
+	visibility = COALESCE($3, visibility)
+WHERE id = $4 AND owner_id = $5;
+	`
+	res, err := r.db.ExecContext(ctx, query, list.Name, list.Description,
+		list.Visibility, list.ID, list.OwnerID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return &model.ConflictError{Message: fmt.Sprintf("list %q already exists", *list.Name)}
+	}
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("list %d doesn't exist", *list.ID)}
+	}
+	return nil
+}
+
+func (r *listRepository) Delete(ctx context.Context, listID, ownerID int64) error {
+	query := `DELETE FROM lists WHERE id = $1 AND owner_id = $2 AND NOT is_default;`
+	res, err := r.db.ExecContext(ctx, query, listID, ownerID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return fmt.Errorf("invalid count of deleted lists %d", count)
+	}
+	return nil
+}
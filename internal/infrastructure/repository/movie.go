@@ -3,102 +3,361 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/kiryu-dev/mykinolist/internal/service"
+	"github.com/lib/pq"
 )
 
+const uniqueViolationCode = "23505"
+
 type movieRepository struct {
 	db *sql.DB
 }
 
+/* Add the movie to the list, if it's in the trash, it must be restored instead */
 func (r *movieRepository) Add(ctx context.Context, movie *model.ListUnit) error {
-	query := `
-INSERT INTO list_titles (list_id, title_id, status_name, score, is_favorite)
-SELECT id, $1, $2, $3, $4
-FROM lists WHERE id = $5;
-	`
-	res, err := r.db.ExecContext(ctx, query, movie.ID, movie.Status,
-		movie.Score, movie.IsFavorite, movie.OwnerID)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	count, err := res.RowsAffected()
-	if err != nil {
+	defer tx.Rollback()
+	if err := addEntry(ctx, tx, movie); err != nil {
 		return err
 	}
-	if count != 1 {
-		return fmt.Errorf("invalid count of added titles %d", count)
-	}
-	return nil
+	return tx.Commit()
 }
 
-func (r *movieRepository) GetAll(ctx context.Context, userID int64) ([]*model.ListUnit, error) {
+func addEntry(ctx context.Context, tx *sql.Tx, movie *model.ListUnit) error {
+	/* replacing the trashed entry would drop its diary, review, tags and history */
 	query := `
-SELECT title_id, status_name, score, is_favorite
-FROM list_titles JOIN lists
-ON list_titles.list_id = lists.id
-AND lists.owner_id = $1
-ORDER BY is_favorite DESC, score DESC; 
+SELECT EXISTS (
+	SELECT 1 FROM list_titles
+	WHERE list_id = $1 AND title_id = $2 AND deleted_at IS NOT NULL
+);
 	`
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	var trashed bool
+	if err := tx.QueryRowContext(ctx, query, movie.ListID, movie.ID).Scan(&trashed); err != nil {
+		return err
+	}
+	if trashed {
+		return &model.ConflictError{Message: fmt.Sprintf(
+			"movie is in the trash, restore it with POST /list/trash/%d/restore", movie.ID)}
+	}
+	/* the new entry goes last in the manual order */
+	if err := lockRanks(ctx, tx, movie.ListID); err != nil {
+		return err
+	}
+	query = `
+SELECT COALESCE(MAX(rank), '') FROM list_titles WHERE list_id = $1;
+	`
+	var last string
+	if err := tx.QueryRowContext(ctx, query, movie.ListID).Scan(&last); err != nil {
+		return err
+	}
+	query = `
+INSERT INTO list_titles (list_id, title_id, status_name, score, is_favorite, rank)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING added_at, updated_at, version;
+	`
+	err := tx.QueryRowContext(ctx, query, movie.ListID, movie.ID, movie.Status, movie.RawScore,
+		movie.IsFavorite, model.RankBetween(last, "")).Scan(&movie.AddedAt, &movie.UpdatedAt, &movie.Version)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return &model.ConflictError{Message: "movie is already in your list"}
+	}
+	return err
+}
+
+/* names of the tags attached to the list title */
+const tagsColumn = `ARRAY(
+	SELECT tags.name FROM list_title_tags JOIN tags ON tags.id = list_title_tags.tag_id
+	WHERE list_title_tags.list_id = list_titles.list_id AND list_title_tags.title_id = list_titles.title_id
+	ORDER BY tags.name)`
+
+/* entries with the same score are in the manual order */
+var listSortKeys = map[string][]sortKey{
+	"": {{"list_titles.is_favorite", "boolean", true}, {"list_titles.score", "smallint", true},
+		{"list_titles.rank", "text", false}},
+	"rank":  {{"list_titles.rank", "text", false}},
+	"score": {{"list_titles.score", "smallint", true}},
+	"added": {{"list_titles.added_at", "timestamp", true}},
+	"name":  {{"COALESCE(movies.name, '')", "text", false}},
+	"year":  {{"COALESCE(movies.year, 0)", "smallint", true}},
+}
+
+/* Get a page of filtered list titles and the cursor of the next page (empty for the last one) */
+func (r *movieRepository) GetAll(ctx context.Context, filter *model.ListFilter) ([]*model.ListUnit, string, error) {
+	var (
+		keys       = sortKeys(listSortKeys[filter.Sort], filter.Order)
+		args       = new(queryArgs)
+		conditions = []string{
+			"list_titles.list_id = " + args.add(filter.ListID),
+			"list_titles.deleted_at IS NULL",
+		}
+	)
+	if filter.Status != "" {
+		conditions = append(conditions, "list_titles.status_name = "+args.add(filter.Status))
+	}
+	if filter.IsFavorite != nil {
+		conditions = append(conditions, "list_titles.is_favorite = "+args.add(*filter.IsFavorite))
+	}
+	if filter.MinRawScore != nil {
+		conditions = append(conditions, "list_titles.score >= "+args.add(*filter.MinRawScore))
+	}
+	if filter.MaxRawScore != nil {
+		conditions = append(conditions, "list_titles.score <= "+args.add(*filter.MaxRawScore))
+	}
+	if filter.Genre != "" {
+		conditions = append(conditions, args.add(filter.Genre)+" = ANY(movies.genres)")
+	}
+	/* the entry must have all of the tags */
+	for _, tag := range filter.Tags {
+		conditions = append(conditions, `EXISTS (
+	SELECT 1 FROM list_title_tags JOIN tags ON tags.id = list_title_tags.tag_id
+	WHERE list_title_tags.list_id = list_titles.list_id
+		AND list_title_tags.title_id = list_titles.title_id AND tags.name = `+args.add(tag)+`)`)
+	}
+	if filter.Year != 0 {
+		conditions = append(conditions, "movies.year = "+args.add(filter.Year))
+	}
+	if filter.Cursor != "" {
+		values, err := decodeCursor(filter.Cursor, len(keys))
+		if err != nil {
+			return nil, "", err
+		}
+		conditions = append(conditions, keysetCondition(keys, values, args))
+	}
+	query := fmt.Sprintf(`
+SELECT list_titles.title_id, %s, status_name, score, is_favorite, added_at, updated_at,
+	completed_at, version, season, episode, %s, %s
+FROM list_titles LEFT JOIN movies
+ON movies.id = list_titles.title_id
+WHERE %s
+ORDER BY %s
+LIMIT %s;
+	`, movieColumns, tagsColumn, sortValuesColumns(keys), strings.Join(conditions, " AND "),
+		orderByClause(keys), args.add(filter.Limit+1))
+	rows, err := r.db.QueryContext(ctx, query, *args...)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	movies := make([]*model.ListUnit, 0)
+	defer rows.Close()
+	var (
+		movies     = make([]*model.ListUnit, 0)
+		values     = make([]string, len(keys))
+		nextCursor string
+	)
 	for rows.Next() {
-		movie := new(model.ListUnit)
-		err := rows.Scan(&movie.ID, &movie.Status, &movie.Score, &movie.IsFavorite)
-		if err != nil {
-			return nil, err
+		if len(movies) == filter.Limit {
+			/* there's one more row, so the page isn't the last one */
+			nextCursor = encodeCursor(values)
+			break
+		}
+		var (
+			movie           = new(model.ListUnit)
+			season, episode int
+		)
+		dest := append([]any{&movie.ID}, movieFields(&movie.Movie)...)
+		dest = append(dest, &movie.Status, &movie.RawScore, &movie.IsFavorite, &movie.AddedAt,
+			&movie.UpdatedAt, &movie.CompletedAt, &movie.Version, &season, &episode, pq.Array(&movie.Tags))
+		for i := range values {
+			dest = append(dest, &values[i])
 		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, "", err
+		}
+		movie.ListID = filter.ListID
+		setProgress(movie, season, episode)
 		movies = append(movies, movie)
 	}
-	return movies, nil
+	return movies, nextCursor, rows.Err()
 }
 
 func (r *movieRepository) GetByID(ctx context.Context, movie *model.ListUnit) error {
+	return getEntry(ctx, r.db, movie)
+}
+
+type rowQueryer interface {
+	QueryRowContext(context.Context, string, ...any) *sql.Row
+}
+
+func getEntry(ctx context.Context, db rowQueryer, movie *model.ListUnit) error {
 	query := `
-SELECT list_id, status_name, score, is_favorite
-FROM list_titles JOIN lists
-ON list_titles.list_id = lists.id
-AND lists.owner_id = $1
-AND list_titles.title_id = $2;
+SELECT ` + movieColumns + `, status_name, score, is_favorite, added_at, updated_at,
+	completed_at, version, season, episode, ` + tagsColumn + `
+FROM list_titles LEFT JOIN movies
+ON movies.id = list_titles.title_id
+WHERE list_id = $1 AND title_id = $2 AND deleted_at IS NULL;
 	`
-	err := r.db.QueryRowContext(ctx, query, movie.OwnerID, movie.ID).
-		Scan(&movie.ListID, &movie.Status, &movie.Score, &movie.IsFavorite)
+	var season, episode int
+	dest := append(movieFields(&movie.Movie), &movie.Status, &movie.RawScore, &movie.IsFavorite, &movie.AddedAt,
+		&movie.UpdatedAt, &movie.CompletedAt, &movie.Version, &season, &episode, pq.Array(&movie.Tags))
+	err := db.QueryRowContext(ctx, query, movie.ListID, movie.ID).Scan(dest...)
+	if err == sql.ErrNoRows {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in the list", movie.ID)}
+	}
 	if err != nil {
 		return err
 	}
+	setProgress(movie, season, episode)
 	return nil
 }
 
+/*
+progress is shown for series only, films have nothing to count. If the movie isn't cached,
+it's unknown yet whether it's a series, so the progress is kept until the movie is fetched
+*/
+func setProgress(movie *model.ListUnit, season, episode int) {
+	if movie.IsSeries || movie.Name == "" {
+		movie.Progress = model.NewProgress(season, episode, movie.EpisodesPerSeason)
+	}
+}
+
+/*
+Save the new progress and status of the title. The update only happens if
+the progress is still the one it was computed from, so two concurrent increments
+can't overwrite each other
+*/
+func (r *movieRepository) UpdateProgress(ctx context.Context, movie *model.ListUnit, prev *model.Progress) error {
+	query := `
+UPDATE list_titles
+SET season = $1, episode = $2, status_name = $3
+WHERE list_id = $4 AND title_id = $5 AND season = $6 AND episode = $7 AND deleted_at IS NULL
+RETURNING updated_at, completed_at, version;
+	`
+	err := r.db.QueryRowContext(ctx, query, movie.Progress.Season, movie.Progress.Episode,
+		movie.Status, movie.ListID, movie.ID, prev.Season, prev.Episode).Scan(
+		&movie.UpdatedAt, &movie.CompletedAt, &movie.Version,
+	)
+	if err == sql.ErrNoRows {
+		return &model.ConflictError{Message: "progress has been changed concurrently, try again"}
+	}
+	return err
+}
+
+/*
+Update the non-nil fields of the entry in one statement. The row is locked
+until the end of the transaction, so the version can't change between the check and the update
+*/
 func (r *movieRepository) Update(ctx context.Context, movie *model.ListUnitPatch) error {
-	var err error
-	if movie.Status != nil {
-		err = r.updateStatus(ctx, movie)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
+	version, err := updateEntry(ctx, tx, movie)
 	if err != nil {
 		return err
 	}
-	if movie.Score != nil {
-		err = r.updateScore(ctx, movie)
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	movie.Version = &version
+	return nil
+}
+
+/* the new version of the entry is returned */
+func updateEntry(ctx context.Context, tx *sql.Tx, movie *model.ListUnitPatch) (int64, error) {
+	query := `
+SELECT version FROM list_titles
+WHERE list_id = $1 AND title_id = $2 AND deleted_at IS NULL
+FOR UPDATE;
+	`
+	var version int64
+	err := tx.QueryRowContext(ctx, query, movie.ListID, movie.MovieID).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in the list", *movie.MovieID)}
 	}
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if movie.Version != nil && *movie.Version != version {
+		return 0, &model.PreconditionFailedError{
+			Message: fmt.Sprintf("movie has been changed, its current version is %d", version),
+		}
+	}
+	var (
+		args = new(queryArgs)
+		set  = make([]string, 0, 3)
+	)
+	if movie.Status != nil {
+		set = append(set, "status_name = "+args.add(*movie.Status))
+	}
+	if movie.RawScore != nil {
+		set = append(set, "score = "+args.add(*movie.RawScore))
 	}
 	if movie.IsFavorite != nil {
-		err = r.updateIsFavoriteField(ctx, movie)
+		set = append(set, "is_favorite = "+args.add(*movie.IsFavorite))
 	}
-	return err
+	query = fmt.Sprintf(`
+UPDATE list_titles
+SET %s
+WHERE list_id = %s AND title_id = %s
+RETURNING version;
+	`, strings.Join(set, ", "), args.add(*movie.ListID), args.add(*movie.MovieID))
+	err = tx.QueryRowContext(ctx, query, *args...).Scan(&version)
+	return version, err
+}
+
+/* Status and score changes of the list entry in chronological order */
+func (r *movieRepository) GetHistory(ctx context.Context, movie *model.ListUnit) ([]*model.HistoryEntry, error) {
+	query := `
+SELECT id, title_id, old_status_name, new_status_name, old_score, new_score, changed_at
+FROM list_titles_history
+WHERE list_id = $1 AND title_id = $2
+ORDER BY changed_at, id;
+	`
+	rows, err := r.db.QueryContext(ctx, query, movie.ListID, movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	history := make([]*model.HistoryEntry, 0)
+	for rows.Next() {
+		var (
+			entry     = new(model.HistoryEntry)
+			oldStatus sql.NullString
+			oldScore  sql.NullInt16
+		)
+		err := rows.Scan(&entry.ID, &entry.MovieID, &oldStatus, &entry.Status,
+			&oldScore, &entry.RawScore, &entry.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		if oldStatus.Valid {
+			entry.OldStatus = &oldStatus.String
+		}
+		if oldScore.Valid {
+			score := uint8(oldScore.Int16)
+			entry.OldRawScore = &score
+		}
+		history = append(history, entry)
+	}
+	return history, rows.Err()
 }
 
+/* Move the entry to the trash, it's purged after the retention period */
 func (r *movieRepository) Delete(ctx context.Context, movie *model.ListUnit) error {
+	return deleteEntry(ctx, r.db, movie)
+}
+
+/* *sql.DB or *sql.Tx */
+type execer interface {
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+}
+
+func deleteEntry(ctx context.Context, db execer, movie *model.ListUnit) error {
 	query := `
-DELETE FROM list_titles
-WHERE list_id = $1 AND title_id = $2;
+UPDATE list_titles
+SET deleted_at = NOW()
+WHERE list_id = $1 AND title_id = $2 AND deleted_at IS NULL;
 	`
-	res, err := r.db.ExecContext(ctx, query, movie.ListID, movie.ID)
+	res, err := db.ExecContext(ctx, query, movie.ListID, movie.ID)
 	if err != nil {
 		return err
 	}
@@ -106,59 +365,218 @@ WHERE list_id = $1 AND title_id = $2;
 	if err != nil {
 		return err
 	}
+	if count == 0 {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in the list", movie.ID)}
+	}
 	if count != 1 {
 		return fmt.Errorf("invalid count of deleted titles %d", count)
 	}
 	return nil
 }
 
-func (r *movieRepository) updateStatus(ctx context.Context, movie *model.ListUnitPatch) error {
-	query := `
-UPDATE list_titles
-SET status_name = $1
-WHERE list_id = $2 AND title_id = $3;
-	`
-	res, err := r.db.ExecContext(ctx, query, movie.Status, movie.ListID, movie.MovieID)
+/*
+Apply the prepared operations of the batch in one transaction, the failed operations get the error.
+Every operation of a non-atomic batch runs within a savepoint, so its failure doesn't abort the
+transaction; the first failure of an atomic batch stops the batch and nothing is committed
+*/
+func (r *movieRepository) Batch(ctx context.Context, batch *model.Batch) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	count, err := res.RowsAffected()
-	if err != nil {
+	defer tx.Rollback()
+	for _, op := range batch.Operations {
+		if op.Err != nil {
+			continue
+		}
+		if !batch.Atomic {
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT batch_operation;"); err != nil {
+				return err
+			}
+		}
+		op.Err = applyOperation(ctx, tx, op)
+		switch {
+		case op.Err != nil && batch.Atomic:
+			return nil
+		case op.Err != nil:
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_operation;"); err != nil {
+				return err
+			}
+		case !batch.Atomic:
+			if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT batch_operation;"); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+/*
+The entry to update is read within the transaction, so it may have been added by an earlier
+operation of the batch. Its previous state tells the actual changes to be shown in the feed
+*/
+func applyOperation(ctx context.Context, tx *sql.Tx, op *model.BatchOperation) error {
+	switch op.Op {
+	case model.BatchAdd:
+		return addEntry(ctx, tx, op.Movie)
+	case model.BatchDelete:
+		return deleteEntry(ctx, tx, op.Movie)
+	}
+	if err := getEntry(ctx, tx, op.Movie); err != nil {
 		return err
 	}
-	if count != 1 {
-		return fmt.Errorf("invalid count of updated titles %d", count)
+	version, err := updateEntry(ctx, tx, op.Patch)
+	if err != nil {
+		return err
 	}
+	op.Patch.Version = &version
 	return nil
 }
 
-func (r *movieRepository) updateScore(ctx context.Context, movie *model.ListUnitPatch) error {
-	query := `
-UPDATE list_titles
-SET score = $1
-WHERE list_id = $2 AND title_id = $3;
-	`
-	res, err := r.db.ExecContext(ctx, query, movie.Score, movie.ListID, movie.MovieID)
+/*
+Change ranks of the list entries in one transaction: rerank reads the current ranks and
+returns the new ones. Ranking of the list is locked meanwhile, so that concurrent moves
+don't put different movies between the same neighbours
+*/
+func (r *movieRepository) Rerank(ctx context.Context, listID int64,
+	rerank func(service.RankReader) (map[int64]string, error)) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	count, err := res.RowsAffected()
+	defer tx.Rollback()
+	if err := lockRanks(ctx, tx, listID); err != nil {
+		return err
+	}
+	ranks, err := rerank(&rankReader{tx: tx, listID: listID})
 	if err != nil {
 		return err
 	}
-	if count != 1 {
-		return fmt.Errorf("invalid count of updated titles %d", count)
+	if len(ranks) == 0 {
+		return nil
 	}
-	return nil
+	if err := setRanks(ctx, tx, listID, ranks); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+/* the list row isn't changed, the lock only serializes the transactions ranking its entries */
+func lockRanks(ctx context.Context, tx *sql.Tx, listID int64) error {
+	query := `SELECT id FROM lists WHERE id = $1 FOR NO KEY UPDATE;`
+	var id int64
+	return tx.QueryRowContext(ctx, query, listID).Scan(&id)
+}
+
+type rankReader struct {
+	tx     *sql.Tx
+	listID int64
+}
+
+/* Ranks of the entries, every movie must be in the list */
+func (r *rankReader) GetRanks(ctx context.Context, ids []int64) (map[int64]string, error) {
+	query := `
+SELECT title_id, rank FROM list_titles
+WHERE list_id = $1 AND title_id = ANY($2) AND deleted_at IS NULL;
+	`
+	rows, err := r.tx.QueryContext(ctx, query, r.listID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ranks := make(map[int64]string, len(ids))
+	for rows.Next() {
+		var (
+			id   int64
+			rank string
+		)
+		if err := rows.Scan(&id, &rank); err != nil {
+			return nil, err
+		}
+		ranks[id] = rank
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if _, ok := ranks[id]; !ok {
+			return nil, &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in the list", id)}
+		}
+	}
+	return ranks, nil
+}
+
+/* Rank of the entry right after (or before) the rank, it's empty if there's no such entry */
+func (r *rankReader) GetNeighborRank(ctx context.Context, rank string, after bool) (string, error) {
+	query := `
+SELECT COALESCE(MAX(rank), '') FROM list_titles
+WHERE list_id = $1 AND rank < $2 AND deleted_at IS NULL;
+	`
+	if after {
+		query = `
+SELECT COALESCE(MIN(rank), '') FROM list_titles
+WHERE list_id = $1 AND rank > $2 AND deleted_at IS NULL;
+		`
+	}
+	var neighbor string
+	err := r.tx.QueryRowContext(ctx, query, r.listID, rank).Scan(&neighbor)
+	return neighbor, err
+}
+
+func setRanks(ctx context.Context, tx *sql.Tx, listID int64, ranks map[int64]string) error {
+	var (
+		ids    = make([]int64, 0, len(ranks))
+		values = make([]string, 0, len(ranks))
+	)
+	for id, rank := range ranks {
+		ids = append(ids, id)
+		values = append(values, rank)
+	}
+	query := `
+UPDATE list_titles SET rank = new.rank
+FROM unnest($2::integer[], $3::text[]) AS new (title_id, rank)
+WHERE list_titles.list_id = $1 AND list_titles.title_id = new.title_id;
+	`
+	_, err := tx.ExecContext(ctx, query, listID, pq.Array(ids), pq.Array(values))
+	return err
+}
+
+/* Trashed entries of the list, the last deleted go first */
+func (r *movieRepository) GetTrash(ctx context.Context, list *model.ListInfo) ([]*model.ListUnit, error) {
+	query := `
+SELECT list_titles.title_id, ` + movieColumns + `, status_name, score, is_favorite,
+	added_at, updated_at, completed_at, deleted_at, version
+FROM list_titles LEFT JOIN movies
+ON movies.id = list_titles.title_id
+WHERE list_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, title_id;
+	`
+	rows, err := r.db.QueryContext(ctx, query, list.ListID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	movies := make([]*model.ListUnit, 0)
+	for rows.Next() {
+		movie := &model.ListUnit{ListInfo: *list}
+		dest := append([]any{&movie.ID}, movieFields(&movie.Movie)...)
+		dest = append(dest, &movie.Status, &movie.RawScore, &movie.IsFavorite, &movie.AddedAt,
+			&movie.UpdatedAt, &movie.CompletedAt, &movie.DeletedAt, &movie.Version)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+	return movies, rows.Err()
 }
 
-func (r *movieRepository) updateIsFavoriteField(ctx context.Context, movie *model.ListUnitPatch) error {
+func (r *movieRepository) Restore(ctx context.Context, movie *model.ListUnit) error {
 	query := `
 UPDATE list_titles
-SET is_favorite = $1
-WHERE list_id = $2 AND title_id = $3;
+SET deleted_at = NULL
+WHERE list_id = $1 AND title_id = $2 AND deleted_at IS NOT NULL;
 	`
-	res, err := r.db.ExecContext(ctx, query, movie.IsFavorite, movie.ListID, movie.MovieID)
+	res, err := r.db.ExecContext(ctx, query, movie.ListID, movie.ID)
 	if err != nil {
 		return err
 	}
@@ -167,7 +585,81 @@ WHERE list_id = $2 AND title_id = $3;
 		return err
 	}
 	if count != 1 {
-		return fmt.Errorf("invalid count of updated titles %d", count)
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in the trash", movie.ID)}
 	}
 	return nil
 }
+
+/* Permanently delete the entries trashed before the given time */
+func (r *movieRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM list_titles WHERE deleted_at < $1;`
+	res, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+/*
+Entries of the list that are connected to other movies by sequels and prequels. The trashed
+entries are included if they're parts of the listed movies, but they don't join collections
+*/
+func (r *movieRepository) GetSequelEntries(ctx context.Context, list *model.ListInfo) ([]*model.ListUnit, error) {
+	query := `
+SELECT list_titles.title_id, ` + movieColumns + `, status_name, list_titles.deleted_at
+FROM list_titles LEFT JOIN movies
+ON movies.id = list_titles.title_id
+WHERE list_id = $1 AND (
+	list_titles.deleted_at IS NULL
+		AND EXISTS (SELECT 1 FROM movie_sequels WHERE movie_sequels.movie_id = list_titles.title_id)
+	OR EXISTS (SELECT 1 FROM movie_sequels
+		JOIN list_titles AS listed ON listed.title_id = movie_sequels.movie_id
+		WHERE listed.list_id = $1 AND listed.deleted_at IS NULL
+			AND movie_sequels.sequel_id = list_titles.title_id));
+	`
+	rows, err := r.db.QueryContext(ctx, query, list.ListID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	movies := make([]*model.ListUnit, 0)
+	for rows.Next() {
+		movie := &model.ListUnit{ListInfo: *list}
+		dest := append([]any{&movie.ID}, movieFields(&movie.Movie)...)
+		dest = append(dest, &movie.Status, &movie.DeletedAt)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+	return movies, rows.Err()
+}
+
+/* Sequels and prequels of the listed movies, the year of a cached part comes from its cache */
+func (r *movieRepository) GetSequels(ctx context.Context, list *model.ListInfo) ([]*model.Sequel, error) {
+	query := `
+SELECT movie_sequels.movie_id, movie_sequels.sequel_id, movie_sequels.name,
+	COALESCE(movie_sequels.en_name, ''), COALESCE(movie_sequels.type, ''),
+	COALESCE(movie_sequels.poster_url, ''), COALESCE(NULLIF(movies.year, 0), movie_sequels.year, 0)
+FROM movie_sequels
+JOIN list_titles ON list_titles.title_id = movie_sequels.movie_id
+LEFT JOIN movies ON movies.id = movie_sequels.sequel_id
+WHERE list_titles.list_id = $1 AND list_titles.deleted_at IS NULL;
+	`
+	rows, err := r.db.QueryContext(ctx, query, list.ListID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	sequels := make([]*model.Sequel, 0)
+	for rows.Next() {
+		sequel := new(model.Sequel)
+		err := rows.Scan(&sequel.MovieID, &sequel.Part.ID, &sequel.Part.Name, &sequel.Part.EnName,
+			&sequel.Part.Type, &sequel.Part.PosterURL, &sequel.Part.Year)
+		if err != nil {
+			return nil, err
+		}
+		sequels = append(sequels, sequel)
+	}
+	return sequels, rows.Err()
+}
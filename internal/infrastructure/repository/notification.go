@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type notificationRepository struct {
+	db *sql.DB
+}
+
+/*
+Notify the owners of the movies planned to watch whose premiere or digital release
+has come. Only the dates that have come while the movie is in the list count, and
+every release is notified once, even if it has been dismissed
+*/
+func (r *notificationRepository) CreateReleased(ctx context.Context, today time.Time) ([]*model.Notification, error) {
+	query := `
+WITH created AS (
+	INSERT INTO notifications (user_id, title_id, kind, release_date)
+	SELECT DISTINCT lists.owner_id, list_titles.title_id, releases.kind, releases.date
+	FROM list_titles
+	JOIN lists ON lists.id = list_titles.list_id
+	JOIN movies ON movies.id = list_titles.title_id
+	CROSS JOIN LATERAL (
+		VALUES ('premiere', movies.premiere), ('digital_release', movies.digital_release)
+	) AS releases (kind, date)
+	WHERE list_titles.status_name = 'plan to watch' AND list_titles.deleted_at IS NULL
+		AND releases.date <= $1::date AND releases.date >= list_titles.added_at::date
+	ON CONFLICT (user_id, title_id, kind) DO NOTHING
+	RETURNING id, user_id, title_id, kind, release_date, created_at
+)
+SELECT created.id, created.user_id, created.kind, created.release_date, created.created_at,
+	created.title_id, ` + movieColumns + `
+FROM created LEFT JOIN movies ON movies.id = created.title_id
+ORDER BY created.user_id, created.id;
+	`
+	return r.getNotifications(ctx, query, today)
+}
+
+/* Notifications of the user that haven't been dismissed, newest first */
+func (r *notificationRepository) GetAll(ctx context.Context, userID int64) ([]*model.Notification, error) {
+	query := `
+SELECT notifications.id, notifications.user_id, notifications.kind, notifications.release_date,
+	notifications.created_at, notifications.title_id, ` + movieColumns + `
+FROM notifications LEFT JOIN movies ON movies.id = notifications.title_id
+WHERE notifications.user_id = $1 AND notifications.dismissed_at IS NULL
+ORDER BY notifications.created_at DESC, notifications.id DESC;
+	`
+	return r.getNotifications(ctx, query, userID)
+}
+
+func (r *notificationRepository) getNotifications(ctx context.Context, query string, args ...any) ([]*model.Notification, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	notifications := make([]*model.Notification, 0)
+	for rows.Next() {
+		notification := new(model.Notification)
+		dest := []any{&notification.ID, &notification.UserID, &notification.Kind,
+			&notification.ReleaseDate, &notification.CreatedAt, &notification.Movie.ID}
+		dest = append(dest, movieFields(&notification.Movie)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func (r *notificationRepository) Dismiss(ctx context.Context, userID, id int64) error {
+	query := `
+UPDATE notifications SET dismissed_at = NOW()
+WHERE id = $1 AND user_id = $2 AND dismissed_at IS NULL;
+	`
+	res, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("notification %d doesn't exist", id)}
+	}
+	return nil
+}
+
+func (r *notificationRepository) DismissAll(ctx context.Context, userID int64) (int64, error) {
+	query := `
+UPDATE notifications SET dismissed_at = NOW()
+WHERE user_id = $1 AND dismissed_at IS NULL;
+	`
+	res, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/* positional arguments of a query that is built on the fly */
+type queryArgs []any
+
+func (a *queryArgs) add(value any) string {
+	*a = append(*a, value)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+type sortKey struct {
+	expr string // SQL expression the rows are ordered by
+	cast string // SQL type the cursor value is converted to
+	desc bool
+}
+
+/*
+Every sort ends with the title ID, so the order is total and
+a page can be continued from the sort key values of its last row
+*/
+func sortKeys(keys []sortKey, order string) []sortKey {
+	res := make([]sortKey, 0, len(keys)+1)
+	for _, key := range keys {
+		switch order {
+		case "asc":
+			key.desc = false
+		case "desc":
+			key.desc = true
+		}
+		res = append(res, key)
+	}
+	return append(res, sortKey{"list_titles.title_id", "integer", res[len(res)-1].desc})
+}
+
+func orderByClause(keys []sortKey) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		direction := "ASC"
+		if key.desc {
+			direction = "DESC"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", key.expr, direction))
+	}
+	return strings.Join(parts, ", ")
+}
+
+/* sort key values are selected as text to build the next cursor */
+func sortValuesColumns(keys []sortKey) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("(%s)::text", key.expr))
+	}
+	return strings.Join(parts, ", ")
+}
+
+/*
+Rows after the cursor: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...,
+where ">" turns into "<" for keys in descending order
+*/
+func keysetCondition(keys []sortKey, values []string, args *queryArgs) string {
+	placeholders := make([]string, 0, len(keys))
+	for i, key := range keys {
+		placeholders = append(placeholders, fmt.Sprintf("%s::%s", args.add(values[i]), key.cast))
+	}
+	ors := make([]string, 0, len(keys))
+	for i, key := range keys {
+		ands := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, fmt.Sprintf("%s = %s", keys[j].expr, placeholders[j]))
+		}
+		op := ">"
+		if key.desc {
+			op = "<"
+		}
+		ands = append(ands, fmt.Sprintf("%s %s %s", key.expr, op, placeholders[i]))
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return "(" + strings.Join(ors, " OR ") + ")"
+}
+
+func encodeCursor(values []string) string {
+	data, _ := json.Marshal(values)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string, keysCount int) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	values := make([]string, 0, keysCount)
+	if err := json.Unmarshal(data, &values); err != nil || len(values) != keysCount {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return values, nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/lib/pq"
+)
+
+type recommendationRepository struct {
+	db *sql.DB
+}
+
+/*
+A rating of the user is the 0-100 score of the title (100 for favorites) centered at the middle
+of the scale, so that low scores tell the titles are unlike rather than alike
+*/
+const ratingExpr = `MAX(CASE WHEN list_titles.is_favorite THEN 100 ELSE list_titles.score END) - 50.5`
+
+/*
+Recompute the cosine similarity of every pair of titles rated by at least minCommonUsers
+common users. Only the given count of the most similar neighbors of each title is kept
+*/
+func (r *recommendationRepository) ComputeSimilarities(ctx context.Context, minCommonUsers, neighbors int) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM movie_similarities;`); err != nil {
+		return 0, err
+	}
+	query := `
+WITH ratings AS (
+	SELECT lists.owner_id AS user_id, list_titles.title_id, ` + ratingExpr + ` AS rating
+	FROM list_titles JOIN lists ON lists.id = list_titles.list_id
+	WHERE list_titles.deleted_at IS NULL AND (list_titles.score > 0 OR list_titles.is_favorite)
+	GROUP BY lists.owner_id, list_titles.title_id
+), norms AS (
+	SELECT title_id, SQRT(SUM(rating * rating)) AS norm
+	FROM ratings GROUP BY title_id
+), pairs AS (
+	SELECT a.title_id, b.title_id AS similar_id,
+		SUM(a.rating * b.rating) / (norm_a.norm * norm_b.norm) AS similarity,
+		COUNT(*) AS common_users
+	FROM ratings a JOIN ratings b
+	ON b.user_id = a.user_id AND b.title_id <> a.title_id
+	JOIN norms norm_a ON norm_a.title_id = a.title_id
+	JOIN norms norm_b ON norm_b.title_id = b.title_id
+	GROUP BY a.title_id, b.title_id, norm_a.norm, norm_b.norm
+	HAVING COUNT(*) >= $1
+), ranked AS (
+	SELECT title_id, similar_id, similarity, common_users,
+		ROW_NUMBER() OVER (PARTITION BY title_id ORDER BY similarity DESC, common_users DESC) AS rank
+	FROM pairs WHERE similarity > 0
+)
+INSERT INTO movie_similarities (title_id, similar_id, similarity, common_users)
+SELECT title_id, similar_id, similarity, common_users
+FROM ranked WHERE rank <= $2;
+	`
+	res, err := tx.ExecContext(ctx, query, minCommonUsers, neighbors)
+	if err != nil {
+		return 0, err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return count, tx.Commit()
+}
+
+/* Titles similar to the ones rated by the user, weighted by the user's ratings */
+func (r *recommendationRepository) GetSimilar(ctx context.Context, userID int64, limit int) ([]*model.Recommendation, error) {
+	query := `
+WITH ratings AS (
+	SELECT list_titles.title_id, ` + ratingExpr + ` AS rating
+	FROM list_titles JOIN lists ON lists.id = list_titles.list_id
+	WHERE lists.owner_id = $1 AND list_titles.deleted_at IS NULL
+		AND (list_titles.score > 0 OR list_titles.is_favorite)
+	GROUP BY list_titles.title_id
+)
+SELECT movie_similarities.similar_id, ` + movieColumns + `,
+	SUM(movie_similarities.similarity * ratings.rating) AS relevance
+FROM ratings JOIN movie_similarities
+ON movie_similarities.title_id = ratings.title_id
+LEFT JOIN movies ON movies.id = movie_similarities.similar_id
+WHERE ` + notListedCondition("movie_similarities.similar_id") + `
+GROUP BY movie_similarities.similar_id, movies.id
+HAVING SUM(movie_similarities.similarity * ratings.rating) > 0
+ORDER BY relevance DESC, movie_similarities.similar_id
+LIMIT $2;
+	`
+	return r.getRecommendations(ctx, model.RecommendationSimilar, query, userID, limit)
+}
+
+/*
+Titles of the genres the user watches most, popular titles go first. For new accounts
+there are no genres of their own, so it turns into the most popular titles
+*/
+func (r *recommendationRepository) GetByGenres(ctx context.Context, userID int64, exclude []int64, limit int) ([]*model.Recommendation, error) {
+	query := `
+WITH user_genres AS (
+	SELECT genre, COUNT(*) AS weight
+	FROM list_titles JOIN lists ON lists.id = list_titles.list_id
+	JOIN movies ON movies.id = list_titles.title_id
+	CROSS JOIN LATERAL unnest(movies.genres) AS genre
+	WHERE lists.owner_id = $1 AND list_titles.deleted_at IS NULL
+	GROUP BY genre
+), popularity AS (
+	SELECT title_id, COUNT(*) AS users
+	FROM list_titles WHERE deleted_at IS NULL
+	GROUP BY title_id
+)
+SELECT movies.id, ` + movieColumns + `,
+	COALESCE(SUM(user_genres.weight), 0)::float8 /
+		GREATEST((SELECT SUM(weight) FROM user_genres), 1) AS relevance
+FROM movies
+LEFT JOIN LATERAL unnest(movies.genres) AS movie_genres (genre) ON TRUE
+LEFT JOIN user_genres ON user_genres.genre = movie_genres.genre
+LEFT JOIN popularity ON popularity.title_id = movies.id
+WHERE movies.id <> ALL($3) AND ` + notListedCondition("movies.id") + `
+GROUP BY movies.id, popularity.users
+ORDER BY relevance DESC, COALESCE(popularity.users, 0) DESC, movies.kp_rating DESC, movies.id
+LIMIT $2;
+	`
+	return r.getRecommendations(ctx, model.RecommendationGenre, query, userID, limit, pq.Array(exclude))
+}
+
+/* the title isn't in any list of the user $1 */
+func notListedCondition(titleID string) string {
+	return `NOT EXISTS (
+	SELECT 1 FROM list_titles JOIN lists ON lists.id = list_titles.list_id
+	WHERE lists.owner_id = $1 AND list_titles.title_id = ` + titleID + `
+		AND list_titles.deleted_at IS NULL)`
+}
+
+func (r *recommendationRepository) getRecommendations(ctx context.Context, source, query string, args ...any) ([]*model.Recommendation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	recommendations := make([]*model.Recommendation, 0)
+	for rows.Next() {
+		recommendation := &model.Recommendation{Source: source}
+		dest := append([]any{&recommendation.ID}, movieFields(&recommendation.Movie)...)
+		dest = append(dest, &recommendation.Relevance)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	return recommendations, rows.Err()
+}
@@ -11,6 +11,16 @@ type Repository struct {
 	service.TokenRepository
 	service.ListRepository
 	service.MovieRepositroy
+	service.MovieCacheRepository
+	service.ViewingRepository
+	service.ReviewRepository
+	service.TagRepository
+	service.FollowRepository
+	service.ActivityRepository
+	service.RecommendationRepository
+	service.StatusRepository
+	service.NotificationRepository
+	service.ImportRepository
 }
 
 func New(db *sql.DB) *Repository {
@@ -19,5 +29,15 @@ func New(db *sql.DB) *Repository {
 		&tokenRepository{db},
 		&listRepository{db},
 		&movieRepository{db},
+		&movieCacheRepository{db},
+		&viewingRepository{db},
+		&reviewRepository{db},
+		&tagRepository{db},
+		&followRepository{db},
+		&activityRepository{db},
+		&recommendationRepository{db},
+		&statusRepository{db},
+		&notificationRepository{db},
+		&importRepository{db},
 	}
 }
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/lib/pq"
+)
+
+type reviewRepository struct {
+	db *sql.DB
+}
+
+func (r *reviewRepository) Add(ctx context.Context, review *model.Review) error {
+	query := `
+INSERT INTO reviews (list_id, title_id, body, has_spoilers, is_public)
+SELECT list_id, title_id, $3, $4, $5 FROM list_titles
+WHERE list_id = $1 AND title_id = $2 AND deleted_at IS NULL
+RETURNING created_at;
+	`
+	err := r.db.QueryRowContext(ctx, query, review.ListID, review.MovieID, review.Body,
+		review.HasSpoilers, review.IsPublic).Scan(&review.CreatedAt)
+	if err == sql.ErrNoRows {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in the list", review.MovieID)}
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return &model.ConflictError{Message: fmt.Sprintf("movie %d has already been reviewed", review.MovieID)}
+	}
+	return err
+}
+
+func (r *reviewRepository) Get(ctx context.Context, review *model.Review) error {
+	query := `
+SELECT body, has_spoilers, is_public, created_at, edited_at
+FROM reviews
+WHERE list_id = $1 AND title_id = $2;
+	`
+	err := r.db.QueryRowContext(ctx, query, review.ListID, review.MovieID).Scan(
+		&review.Body, &review.HasSpoilers, &review.IsPublic, &review.CreatedAt, &review.EditedAt,
+	)
+	if err == sql.ErrNoRows {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d hasn't been reviewed", review.MovieID)}
+	}
+	return err
+}
+
+/* Public reviews of the movie by all users, the latest go first. Authors with private profiles are hidden */
+func (r *reviewRepository) GetPublic(ctx context.Context, movieID int64) ([]*model.Review, error) {
+	query := `
+SELECT reviews.title_id, users.username, reviews.body, reviews.has_spoilers,
+	reviews.created_at, reviews.edited_at
+FROM reviews
+JOIN list_titles ON list_titles.list_id = reviews.list_id AND list_titles.title_id = reviews.title_id
+JOIN lists ON lists.id = reviews.list_id
+JOIN users ON users.id = lists.owner_id
+WHERE reviews.title_id = $1 AND reviews.is_public AND list_titles.deleted_at IS NULL
+	AND users.visibility <> 'private'
+ORDER BY reviews.created_at DESC;
+	`
+	rows, err := r.db.QueryContext(ctx, query, movieID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	reviews := make([]*model.Review, 0)
+	for rows.Next() {
+		review := &model.Review{IsPublic: true}
+		err := rows.Scan(&review.MovieID, &review.Author, &review.Body,
+			&review.HasSpoilers, &review.CreatedAt, &review.EditedAt)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, rows.Err()
+}
+
+func (r *reviewRepository) Update(ctx context.Context, review *model.ReviewPatch) error {
+	query := `
+UPDATE reviews
+SET body = COALESCE($1, body), has_spoilers = COALESCE($2, has_spoilers),
+	is_public = COALESCE($3, is_public), edited_at = NOW()
+WHERE list_id = $4 AND title_id = $5;
+	`
+	res, err := r.db.ExecContext(ctx, query, review.Body, review.HasSpoilers,
+		review.IsPublic, review.ListID, review.MovieID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d hasn't been reviewed", review.MovieID)}
+	}
+	return nil
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, review *model.Review) error {
+	query := `
+DELETE FROM reviews
+WHERE list_id = $1 AND title_id = $2;
+	`
+	res, err := r.db.ExecContext(ctx, query, review.ListID, review.MovieID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d hasn't been reviewed", review.MovieID)}
+	}
+	return nil
+}
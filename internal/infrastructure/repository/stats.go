@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+/*
+Statistics of the list on the 0-100 scale. Unscored movies (score 0) don't affect mean scores,
+watch time is the duration of completed movies, every rewatch from the diary counts once more
+*/
+func (r *movieRepository) GetStats(ctx context.Context, listID int64) (*model.Stats, error) {
+	stats := &model.Stats{
+		ByStatus:  make(map[string]int),
+		RawScores: make(map[uint8]int),
+	}
+	if err := r.countByStatus(ctx, listID, stats); err != nil {
+		return nil, err
+	}
+	if err := r.countByScore(ctx, listID, stats); err != nil {
+		return nil, err
+	}
+	var err error
+	if stats.Genres, err = r.countByMovieField(ctx, listID, "unnest(movies.genres)"); err != nil {
+		return nil, err
+	}
+	if stats.Countries, err = r.countByMovieField(ctx, listID, "unnest(movies.countries)"); err != nil {
+		return nil, err
+	}
+	stats.Decades, err = r.countByMovieField(ctx, listID,
+		"(SELECT (movies.year / 10 * 10)::text || 's' WHERE movies.year > 0)")
+	if err != nil {
+		return nil, err
+	}
+	query := `
+SELECT COALESCE(SUM(movies.duration * (1 + (
+	SELECT COUNT(*) FROM viewings
+	WHERE viewings.list_id = list_titles.list_id AND viewings.title_id = list_titles.title_id
+		AND viewings.is_rewatch
+))), 0)
+FROM list_titles JOIN movies
+ON movies.id = list_titles.title_id
+WHERE list_titles.list_id = $1 AND list_titles.deleted_at IS NULL
+	AND list_titles.status_name = 'completed';
+	`
+	if err := r.db.QueryRowContext(ctx, query, listID).Scan(&stats.WatchTime); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *movieRepository) countByStatus(ctx context.Context, listID int64, stats *model.Stats) error {
+	query := `
+SELECT status_name, COUNT(*), COUNT(*) FILTER (WHERE is_favorite)
+FROM list_titles
+WHERE list_id = $1 AND deleted_at IS NULL
+GROUP BY status_name;
+	`
+	rows, err := r.db.QueryContext(ctx, query, listID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			status           string
+			count, favorites int
+		)
+		if err := rows.Scan(&status, &count, &favorites); err != nil {
+			return err
+		}
+		stats.ByStatus[status] = count
+		stats.Total += count
+		stats.Favorites += favorites
+	}
+	return rows.Err()
+}
+
+func (r *movieRepository) countByScore(ctx context.Context, listID int64, stats *model.Stats) error {
+	query := `
+SELECT score, COUNT(*)
+FROM list_titles
+WHERE list_id = $1 AND deleted_at IS NULL AND score > 0
+GROUP BY score;
+	`
+	rows, err := r.db.QueryContext(ctx, query, listID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var sum, count int
+	for rows.Next() {
+		var (
+			score uint8
+			n     int
+		)
+		if err := rows.Scan(&score, &n); err != nil {
+			return err
+		}
+		stats.RawScores[score] = n
+		sum += int(score) * n
+		count += n
+	}
+	if count > 0 {
+		stats.MeanScore = roundScore(float64(sum) / float64(count))
+	}
+	return rows.Err()
+}
+
+/* Movies grouped by the value of the expression over the movies table, the most frequent go first */
+func (r *movieRepository) countByMovieField(ctx context.Context, listID int64, expr string) ([]*model.StatsBucket, error) {
+	query := fmt.Sprintf(`
+SELECT bucket, COUNT(*), COALESCE(AVG(NULLIF(list_titles.score, 0)), 0)
+FROM list_titles JOIN movies
+ON movies.id = list_titles.title_id
+CROSS JOIN LATERAL %s AS buckets (bucket)
+WHERE list_titles.list_id = $1 AND list_titles.deleted_at IS NULL
+GROUP BY bucket
+ORDER BY COUNT(*) DESC, bucket;
+	`, expr)
+	rows, err := r.db.QueryContext(ctx, query, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	buckets := make([]*model.StatsBucket, 0)
+	for rows.Next() {
+		bucket := new(model.StatsBucket)
+		if err := rows.Scan(&bucket.Name, &bucket.Count, &bucket.MeanScore); err != nil {
+			return nil, err
+		}
+		bucket.MeanScore = roundScore(bucket.MeanScore)
+		buckets = append(buckets, bucket)
+	}
+	return buckets, rows.Err()
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/lib/pq"
+)
+
+type statusRepository struct {
+	db *sql.DB
+}
+
+func (r *statusRepository) CreateDefaults(ctx context.Context, ownerID int64) error {
+	query := `
+INSERT INTO statuses (owner_id, name, position, is_builtin)
+SELECT $1, defaults.name, defaults.position, TRUE
+FROM unnest($2::text[]) WITH ORDINALITY AS defaults (name, position);
+	`
+	_, err := r.db.ExecContext(ctx, query, ownerID, pq.Array(model.DefaultStatuses[:]))
+	return err
+}
+
+/* The new status goes last */
+func (r *statusRepository) Create(ctx context.Context, status *model.Status) error {
+	query := `
+INSERT INTO statuses (owner_id, name, position)
+SELECT $1, $2, COALESCE(MAX(position), 0) + 1 FROM statuses WHERE owner_id = $1
+RETURNING id, position;
+	`
+	err := r.db.QueryRowContext(ctx, query, status.OwnerID, status.Name).Scan(&status.ID, &status.Position)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return &model.ConflictError{Message: fmt.Sprintf("status %q already exists", status.Name)}
+	}
+	return err
+}
+
+func (r *statusRepository) GetAll(ctx context.Context, ownerID int64) ([]*model.Status, error) {
+	query := `
+SELECT id, owner_id, name, position, is_builtin FROM statuses
+WHERE owner_id = $1
+ORDER BY position, id;
+	`
+	rows, err := r.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	statuses := make([]*model.Status, 0)
+	for rows.Next() {
+		status := new(model.Status)
+		err := rows.Scan(&status.ID, &status.OwnerID, &status.Name, &status.Position, &status.IsBuiltin)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, rows.Err()
+}
+
+/* The entries of the status are renamed along with it */
+func (r *statusRepository) Rename(ctx context.Context, status *model.Status) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	oldName, err := lockCustomStatus(ctx, tx, status.ID, status.OwnerID)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE statuses SET name = $1 WHERE id = $2;`
+	_, err = tx.ExecContext(ctx, query, status.Name, status.ID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return &model.ConflictError{Message: fmt.Sprintf("status %q already exists", status.Name)}
+	}
+	if err != nil {
+		return err
+	}
+	if err := renameEntries(ctx, tx, status.OwnerID, oldName, status.Name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+/* IDs must be all of the user's statuses */
+func (r *statusRepository) Reorder(ctx context.Context, order *model.StatusOrder) error {
+	query := `
+UPDATE statuses SET position = array_position($2::integer[], id)
+WHERE owner_id = $1;
+	`
+	_, err := r.db.ExecContext(ctx, query, order.OwnerID, pq.Array(order.IDs))
+	return err
+}
+
+/* Move the entries of the status to the target one, then delete the status */
+func (r *statusRepository) Remove(ctx context.Context, removal *model.StatusRemoval) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	name, err := lockCustomStatus(ctx, tx, removal.ID, removal.OwnerID)
+	if err != nil {
+		return err
+	}
+	var targetName string
+	query := `SELECT name FROM statuses WHERE id = $1 AND owner_id = $2;`
+	err = tx.QueryRowContext(ctx, query, removal.TargetID, removal.OwnerID).Scan(&targetName)
+	if err == sql.ErrNoRows {
+		return &model.NotFoundError{Message: fmt.Sprintf("status %d doesn't exist", removal.TargetID)}
+	}
+	if err != nil {
+		return err
+	}
+	if err := moveEntries(ctx, tx, removal.OwnerID, name, targetName); err != nil {
+		return err
+	}
+	query = `DELETE FROM statuses WHERE id = $1;`
+	if _, err := tx.ExecContext(ctx, query, removal.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+/* built-in statuses can be neither renamed nor removed */
+func lockCustomStatus(ctx context.Context, tx *sql.Tx, statusID, ownerID int64) (string, error) {
+	query := `
+SELECT name, is_builtin FROM statuses
+WHERE id = $1 AND owner_id = $2
+FOR UPDATE;
+	`
+	var (
+		name      string
+		isBuiltin bool
+	)
+	err := tx.QueryRowContext(ctx, query, statusID, ownerID).Scan(&name, &isBuiltin)
+	if err == sql.ErrNoRows {
+		return "", &model.NotFoundError{Message: fmt.Sprintf("status %d doesn't exist", statusID)}
+	}
+	if err != nil {
+		return "", err
+	}
+	if isBuiltin {
+		return "", fmt.Errorf("status %q is built-in, it can only be reordered", name)
+	}
+	return name, nil
+}
+
+/*
+Renaming isn't a change of the entries, so the triggers skip the update (see migration 000025):
+the entries keep their versions and timestamps and get no history rows. The history is
+renamed instead, so that it doesn't refer to the old name
+*/
+func renameEntries(ctx context.Context, tx *sql.Tx, ownerID int64, from, to string) error {
+	if _, err := tx.ExecContext(ctx, `SET LOCAL mykinolist.untracked = 'on';`); err != nil {
+		return err
+	}
+	if err := moveEntries(ctx, tx, ownerID, from, to); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `SET LOCAL mykinolist.untracked = 'off';`); err != nil {
+		return err
+	}
+	query := `
+UPDATE list_titles_history
+SET old_status_name = CASE WHEN old_status_name = $1 THEN $2 ELSE old_status_name END,
+	new_status_name = CASE WHEN new_status_name = $1 THEN $2 ELSE new_status_name END
+FROM lists
+WHERE lists.id = list_titles_history.list_id AND lists.owner_id = $3
+	AND (list_titles_history.old_status_name = $1 OR list_titles_history.new_status_name = $1);
+	`
+	_, err := tx.ExecContext(ctx, query, from, to, ownerID)
+	return err
+}
+
+/* entries of all the user's lists, including the trashed ones */
+func moveEntries(ctx context.Context, tx *sql.Tx, ownerID int64, from, to string) error {
+	query := `
+UPDATE list_titles SET status_name = $1
+FROM lists
+WHERE lists.id = list_titles.list_id AND lists.owner_id = $2 AND list_titles.status_name = $3;
+	`
+	_, err := tx.ExecContext(ctx, query, to, ownerID, from)
+	return err
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/lib/pq"
+)
+
+type tagRepository struct {
+	db *sql.DB
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *model.Tag) error {
+	query := `INSERT INTO tags (owner_id, name) VALUES ($1, $2) RETURNING id;`
+	err := r.db.QueryRowContext(ctx, query, tag.OwnerID, tag.Name).Scan(&tag.ID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return &model.ConflictError{Message: fmt.Sprintf("tag %q already exists", tag.Name)}
+	}
+	return err
+}
+
+func (r *tagRepository) GetByID(ctx context.Context, tagID, ownerID int64) (*model.Tag, error) {
+	query := `
+SELECT tags.id, tags.owner_id, tags.name, COUNT(list_titles.title_id)
+FROM tags LEFT JOIN list_title_tags
+ON list_title_tags.tag_id = tags.id
+LEFT JOIN list_titles
+ON list_titles.list_id = list_title_tags.list_id AND list_titles.title_id = list_title_tags.title_id
+	AND list_titles.deleted_at IS NULL
+WHERE tags.id = $1 AND tags.owner_id = $2
+GROUP BY tags.id;
+	`
+	tag := new(model.Tag)
+	err := r.db.QueryRowContext(ctx, query, tagID, ownerID).Scan(
+		&tag.ID, &tag.OwnerID, &tag.Name, &tag.Count,
+	)
+	if err == sql.ErrNoRows {
+		return nil, &model.NotFoundError{Message: fmt.Sprintf("tag %d doesn't exist", tagID)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+/* All tags of the user with counts of the tagged entries */
+func (r *tagRepository) GetAll(ctx context.Context, ownerID int64) ([]*model.Tag, error) {
+	query := `
+SELECT tags.id, tags.owner_id, tags.name, COUNT(list_titles.title_id)
+FROM tags LEFT JOIN list_title_tags
+ON list_title_tags.tag_id = tags.id
+LEFT JOIN list_titles
+ON list_titles.list_id = list_title_tags.list_id AND list_titles.title_id = list_title_tags.title_id
+	AND list_titles.deleted_at IS NULL
+WHERE tags.owner_id = $1
+GROUP BY tags.id
+ORDER BY tags.name;
+	`
+	rows, err := r.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tags := make([]*model.Tag, 0)
+	for rows.Next() {
+		tag := new(model.Tag)
+		if err := rows.Scan(&tag.ID, &tag.OwnerID, &tag.Name, &tag.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+func (r *tagRepository) Rename(ctx context.Context, tag *model.Tag) error {
+	query := `UPDATE tags SET name = $1 WHERE id = $2 AND owner_id = $3;`
+	res, err := r.db.ExecContext(ctx, query, tag.Name, tag.ID, tag.OwnerID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return &model.ConflictError{Message: fmt.Sprintf("tag %q already exists, merge the tags instead", tag.Name)}
+	}
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("tag %d doesn't exist", tag.ID)}
+	}
+	return nil
+}
+
+/* Tag the entries of the source tag with the target one, then delete the source tag */
+func (r *tagRepository) Merge(ctx context.Context, merge *model.TagMerge) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := `
+INSERT INTO list_title_tags (list_id, title_id, tag_id)
+SELECT list_id, title_id, $1 FROM list_title_tags WHERE tag_id = $2
+ON CONFLICT DO NOTHING;
+	`
+	if _, err := tx.ExecContext(ctx, query, merge.TargetID, merge.SourceID); err != nil {
+		return err
+	}
+	query = `DELETE FROM tags WHERE id = $1 AND owner_id = $2;`
+	res, err := tx.ExecContext(ctx, query, merge.SourceID, merge.OwnerID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("tag %d doesn't exist", merge.SourceID)}
+	}
+	return tx.Commit()
+}
+
+func (r *tagRepository) Delete(ctx context.Context, tagID, ownerID int64) error {
+	query := `DELETE FROM tags WHERE id = $1 AND owner_id = $2;`
+	res, err := r.db.ExecContext(ctx, query, tagID, ownerID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("tag %d doesn't exist", tagID)}
+	}
+	return nil
+}
+
+/*
+Attaching the already attached tag is a no-op, but the conflicting row is still
+counted as affected, so no affected rows mean there's no such entry in the list
+*/
+func (r *tagRepository) Attach(ctx context.Context, movie *model.ListUnit, tagID int64) error {
+	query := `
+INSERT INTO list_title_tags (list_id, title_id, tag_id)
+SELECT list_id, title_id, $3 FROM list_titles
+WHERE list_id = $1 AND title_id = $2 AND deleted_at IS NULL
+ON CONFLICT (list_id, title_id, tag_id) DO UPDATE SET tag_id = EXCLUDED.tag_id;
+	`
+	res, err := r.db.ExecContext(ctx, query, movie.ListID, movie.ID, tagID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in the list", movie.ID)}
+	}
+	return nil
+}
+
+func (r *tagRepository) Detach(ctx context.Context, movie *model.ListUnit, tagID int64) error {
+	query := `
+DELETE FROM list_title_tags
+WHERE list_id = $1 AND title_id = $2 AND tag_id = $3;
+	`
+	res, err := r.db.ExecContext(ctx, query, movie.ListID, movie.ID, tagID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't tagged with tag %d", movie.ID, tagID)}
+	}
+	return nil
+}
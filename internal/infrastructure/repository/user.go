@@ -12,6 +12,23 @@ type userRepository struct {
 	db *sql.DB
 }
 
+const userColumns = `id, username, email, hashed_password, created_on, last_login, visibility, score_scale,
+	email_notifications`
+
+/* scan a row of userColumns */
+func (r *userRepository) scanUser(row *sql.Row) (*model.User, error) {
+	user := new(model.User)
+	err := row.Scan(
+		&user.ID, &user.Username, &user.Email, &user.HashedPassword,
+		&user.CreatedOn, &user.LastLogin, &user.Visibility, &user.ScoreScale,
+		&user.EmailNotifications,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 func (r *userRepository) CreateAccount(ctx context.Context, user *model.User) error {
 	query := `
 INSERT INTO users (username, email, hashed_password, created_on, last_login)
@@ -24,16 +41,8 @@ VALUES ($1, $2, $3, $4, $5) RETURNING id;
 }
 
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
-	query := `SELECT * FROM users WHERE email = $1;`
-	user := new(model.User)
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
-		&user.ID, &user.Username, &user.Email,
-		&user.HashedPassword, &user.CreatedOn, &user.LastLogin,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return user, nil
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1;`
+	return r.scanUser(r.db.QueryRowContext(ctx, query, email))
 }
 
 func (r *userRepository) UpdateLastLogin(ctx context.Context, user *model.User) error {
@@ -47,16 +56,39 @@ WHERE id = $2;
 }
 
 func (r *userRepository) FindByID(ctx context.Context, id int64) (*model.User, error) {
-	query := `SELECT * FROM users WHERE id = $1;`
-	user := new(model.User)
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID, &user.Username, &user.Email,
-		&user.HashedPassword, &user.CreatedOn, &user.LastLogin,
-	)
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1;`
+	return r.scanUser(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE username = $1;`
+	user, err := r.scanUser(r.db.QueryRowContext(ctx, query, username))
+	if err == sql.ErrNoRows {
+		return nil, &model.NotFoundError{Message: fmt.Sprintf("user %q doesn't exist", username)}
+	}
+	return user, err
+}
+
+func (r *userRepository) UpdateSettings(ctx context.Context, settings *model.UserSettings) error {
+	query := `
+UPDATE users
+SET visibility = COALESCE($1, visibility), score_scale = COALESCE($2, score_scale),
+	email_notifications = COALESCE($3, email_notifications)
+WHERE id = $4;
+	`
+	res, err := r.db.ExecContext(ctx, query, settings.Visibility, settings.ScoreScale,
+		settings.EmailNotifications, settings.ID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return user, nil
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("user %d doesn't exist", settings.ID)}
+	}
+	return nil
 }
 
 func (r *userRepository) DeleteAccount(ctx context.Context, id int64) error {
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+	"github.com/lib/pq"
+)
+
+const foreignKeyViolationCode = "23503"
+
+type viewingRepository struct {
+	db *sql.DB
+}
+
+/* Record the viewing and mark the movie as completed */
+func (r *viewingRepository) Add(ctx context.Context, viewing *model.Viewing) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := `
+INSERT INTO viewings (list_id, title_id, watched_on, score, is_rewatch, note)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at;
+	`
+	err = tx.QueryRowContext(ctx, query, viewing.ListID, viewing.MovieID, viewing.WatchedOn,
+		viewing.RawScore, viewing.IsRewatch, viewing.Note).Scan(&viewing.ID, &viewing.CreatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in the list", viewing.MovieID)}
+	}
+	if err != nil {
+		return err
+	}
+	query = `
+UPDATE list_titles
+SET status_name = 'completed'
+WHERE list_id = $1 AND title_id = $2 AND deleted_at IS NULL;
+	`
+	res, err := tx.ExecContext(ctx, query, viewing.ListID, viewing.MovieID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	/* the movie is in the trash */
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in the list", viewing.MovieID)}
+	}
+	return tx.Commit()
+}
+
+func (r *viewingRepository) GetAll(ctx context.Context, movie *model.ListUnit) ([]*model.Viewing, error) {
+	query := `
+SELECT id, title_id, watched_on::text, score, is_rewatch, note, created_at
+FROM viewings
+WHERE list_id = $1 AND title_id = $2
+ORDER BY watched_on DESC, id DESC;
+	`
+	rows, err := r.db.QueryContext(ctx, query, movie.ListID, movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	viewings := make([]*model.Viewing, 0)
+	for rows.Next() {
+		viewing := new(model.Viewing)
+		var score sql.NullInt16
+		err := rows.Scan(&viewing.ID, &viewing.MovieID, &viewing.WatchedOn,
+			&score, &viewing.IsRewatch, &viewing.Note, &viewing.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if score.Valid {
+			value := uint8(score.Int16)
+			viewing.RawScore = &value
+		}
+		viewing.ListInfo = movie.ListInfo
+		viewings = append(viewings, viewing)
+	}
+	return viewings, rows.Err()
+}
+
+func (r *viewingRepository) Delete(ctx context.Context, viewing *model.Viewing) error {
+	query := `
+DELETE FROM viewings
+WHERE id = $1 AND list_id = $2 AND title_id = $3;
+	`
+	res, err := r.db.ExecContext(ctx, query, viewing.ID, viewing.ListID, viewing.MovieID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return &model.NotFoundError{Message: fmt.Sprintf("viewing %d doesn't exist", viewing.ID)}
+	}
+	return nil
+}
@@ -5,27 +5,110 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/kiryu-dev/mykinolist/internal/model"
 )
 
 const (
-	urlApiSearchRequest     = "https://api.kinopoisk.dev/v1.2/movie/search?page=1&limit=1&query=%s"
+	urlApiSearchRequest     = "https://api.kinopoisk.dev/v1.2/movie/search?%s"
 	urlApiSearchByIDRequest = "https://api.kinopoisk.dev/v1.3/movie/%d"
+	urlApiFilterRequest     = "https://api.kinopoisk.dev/v1.3/movie?%s"
 )
 
 type KinopoiskWebAPI struct {
 	apiKey string
 }
 
+type kinopoiskName struct {
+	Name string `json:"name"`
+}
+
+/* v1.3/movie/{id} response, only the fields we're interested in */
+type kinopoiskMovie struct {
+	ID              int64           `json:"id"`
+	Name            string          `json:"name"`
+	AlternativeName string          `json:"alternativeName"`
+	EnName          string          `json:"enName"`
+	Type            string          `json:"type"`
+	Year            int             `json:"year"`
+	Genres          []kinopoiskName `json:"genres"`
+	Countries       []kinopoiskName `json:"countries"`
+	MovieLength     int             `json:"movieLength"`
+	AgeRating       int             `json:"ageRating"`
+	Poster          struct {
+		URL string `json:"url"`
+	} `json:"poster"`
+	Rating struct {
+		KP   float64 `json:"kp"`
+		IMDb float64 `json:"imdb"`
+	} `json:"rating"`
+	ExternalID struct {
+		IMDb string `json:"imdb"`
+	} `json:"externalId"`
+	IsSeries    bool `json:"isSeries"`
+	SeasonsInfo []struct {
+		Number        int   `json:"number"`
+		EpisodesCount int64 `json:"episodesCount"`
+	} `json:"seasonsInfo"`
+	Premiere struct {
+		World   *time.Time `json:"world"`
+		Russia  *time.Time `json:"russia"`
+		Digital *time.Time `json:"digital"`
+	} `json:"premiere"`
+	SequelsAndPrequels []struct {
+		ID     int64  `json:"id"`
+		Name   string `json:"name"`
+		EnName string `json:"enName"`
+		Type   string `json:"type"`
+		Year   int    `json:"year"`
+		Poster struct {
+			URL string `json:"url"`
+		} `json:"poster"`
+	} `json:"sequelsAndPrequels"`
+}
+
+/* v1.2/movie/search response: unlike v1.3, genres, countries, poster and rating are flattened */
+type kinopoiskSearchResult struct {
+	Docs []struct {
+		ID              int64    `json:"id"`
+		Name            string   `json:"name"`
+		AlternativeName string   `json:"alternativeName"`
+		EnName          string   `json:"enName"`
+		Type            string   `json:"type"`
+		Year            int      `json:"year"`
+		Genres          []string `json:"genres"`
+		Countries       []string `json:"countries"`
+		MovieLength     int      `json:"movieLength"`
+		AgeRating       int      `json:"ageRating"`
+		Poster          string   `json:"poster"`
+		Rating          float64  `json:"rating"`
+		IsSeries        bool     `json:"isSeries"`
+	} `json:"docs"`
+	Total int `json:"total"`
+	Limit int `json:"limit"`
+	Page  int `json:"page"`
+	Pages int `json:"pages"`
+}
+
 func New(apiKey string) *KinopoiskWebAPI {
 	return &KinopoiskWebAPI{apiKey: apiKey}
 }
 
-func (api *KinopoiskWebAPI) Search(ctx context.Context, title string) (*model.SearchResult, error) {
-	url := fmt.Sprintf(urlApiSearchRequest, title)
-	searchResult := new(model.SearchResult)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+/*
+The search API can't filter by year, so the year only narrows down the candidates
+of the requested page. Pages are still the pages of the whole search, but the total
+count of them isn't known, so it's left out
+*/
+func (api *KinopoiskWebAPI) Search(ctx context.Context, query *model.SearchQuery) (*model.SearchResult, error) {
+	params := url.Values{}
+	params.Set("page", strconv.Itoa(query.Page))
+	params.Set("limit", strconv.Itoa(query.Limit))
+	params.Set("query", query.Query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf(urlApiSearchRequest, params.Encode()), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -35,16 +118,50 @@ func (api *KinopoiskWebAPI) Search(ctx context.Context, title string) (*model.Se
 	if err != nil {
 		return nil, err
 	}
-	if err := json.NewDecoder(resp.Body).Decode(searchResult); err != nil {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kinopoisk api responded with status %d for query %q", resp.StatusCode, query.Query)
+	}
+	kpResult := new(kinopoiskSearchResult)
+	if err := json.NewDecoder(resp.Body).Decode(kpResult); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	searchResult := &model.SearchResult{
+		Docs:  make([]model.Movie, 0, len(kpResult.Docs)),
+		Total: kpResult.Total,
+		Limit: kpResult.Limit,
+		Page:  kpResult.Page,
+		Pages: kpResult.Pages,
+	}
+	if query.Year != 0 {
+		searchResult.Total = 0
+	}
+	for _, doc := range kpResult.Docs {
+		if query.Year != 0 && doc.Year != query.Year {
+			continue
+		}
+		searchResult.Docs = append(searchResult.Docs, model.Movie{
+			ID:              doc.ID,
+			Name:            doc.Name,
+			AlternativeName: doc.AlternativeName,
+			EnName:          doc.EnName,
+			Type:            doc.Type,
+			Year:            doc.Year,
+			Genres:          doc.Genres,
+			Countries:       doc.Countries,
+			Duration:        doc.MovieLength,
+			AgeRating:       doc.AgeRating,
+			PosterURL:       doc.Poster,
+			KinopoiskRating: doc.Rating,
+			IsSeries:        doc.IsSeries,
+		})
+	}
 	return searchResult, nil
 }
 
 func (api *KinopoiskWebAPI) SearchByID(ctx context.Context, id int64) (*model.Movie, error) {
 	url := fmt.Sprintf(urlApiSearchByIDRequest, id)
-	movie := new(model.Movie)
+	movie := new(kinopoiskMovie)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
@@ -55,9 +172,115 @@ func (api *KinopoiskWebAPI) SearchByID(ctx context.Context, id int64) (*model.Mo
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kinopoisk api responded with status %d for movie %d", resp.StatusCode, id)
+	}
 	if err := json.NewDecoder(resp.Body).Decode(movie); err != nil {
 		return nil, err
 	}
+	return movie.toModel(), nil
+}
+
+func (api *KinopoiskWebAPI) SearchByIMDbID(ctx context.Context, imdbID string) (*model.Movie, error) {
+	params := url.Values{}
+	params.Set("externalId.imdb", imdbID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf(urlApiFilterRequest, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-KEY", api.apiKey)
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
-	return movie, nil
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kinopoisk api responded with status %d for movie %s", resp.StatusCode, imdbID)
+	}
+	result := new(struct {
+		Docs []kinopoiskMovie `json:"docs"`
+	})
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	if len(result.Docs) == 0 {
+		return nil, &model.NotFoundError{Message: fmt.Sprintf("movie %s not found", imdbID)}
+	}
+	return result.Docs[0].toModel(), nil
+}
+
+func (m *kinopoiskMovie) toModel() *model.Movie {
+	return &model.Movie{
+		ID:                m.ID,
+		Name:              m.Name,
+		AlternativeName:   m.AlternativeName,
+		EnName:            m.EnName,
+		Type:              m.Type,
+		Year:              m.Year,
+		Genres:            names(m.Genres),
+		Countries:         names(m.Countries),
+		Duration:          m.MovieLength,
+		AgeRating:         m.AgeRating,
+		PosterURL:         m.Poster.URL,
+		KinopoiskRating:   m.Rating.KP,
+		IMDbRating:        m.Rating.IMDb,
+		IMDbID:            m.ExternalID.IMDb,
+		IsSeries:          m.IsSeries,
+		EpisodesPerSeason: m.episodesPerSeason(),
+		Premiere:          m.premiere(),
+		DigitalRelease:    m.Premiere.Digital,
+		Sequels:           m.sequels(),
+	}
+}
+
+func (m *kinopoiskMovie) sequels() []model.Movie {
+	res := make([]model.Movie, 0, len(m.SequelsAndPrequels))
+	for _, sequel := range m.SequelsAndPrequels {
+		res = append(res, model.Movie{
+			ID:        sequel.ID,
+			Name:      sequel.Name,
+			EnName:    sequel.EnName,
+			Type:      sequel.Type,
+			Year:      sequel.Year,
+			PosterURL: sequel.Poster.URL,
+		})
+	}
+	return res
+}
+
+/* the world premiere, if it's unknown (e.g. for local movies), then the russian one */
+func (m *kinopoiskMovie) premiere() *time.Time {
+	if m.Premiere.World != nil {
+		return m.Premiere.World
+	}
+	return m.Premiere.Russia
+}
+
+/* episodes count of the i-th season is at index i-1, specials (season 0) are skipped */
+func (m *kinopoiskMovie) episodesPerSeason() []int64 {
+	if !m.IsSeries {
+		return nil
+	}
+	res := make([]int64, 0, len(m.SeasonsInfo))
+	for _, season := range m.SeasonsInfo {
+		if season.Number < 1 {
+			continue
+		}
+		for len(res) < season.Number {
+			res = append(res, 0)
+		}
+		res[season.Number-1] = season.EpisodesCount
+	}
+	return res
+}
+
+func names(items []kinopoiskName) []string {
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		res = append(res, item.Name)
+	}
+	return res
 }
@@ -0,0 +1,84 @@
+package model
+
+import "fmt"
+
+const maxBatchOperations = 100
+
+/* kinds of batch operations */
+const (
+	BatchAdd    = "add"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+/*
+Operations applied to the list in one transaction. If the batch is atomic, a failed
+operation rolls back all the others, otherwise only the failed operations aren't applied
+*/
+type Batch struct {
+	Operations []*BatchOperation `json:"operations"`
+	Atomic     bool              `json:"atomic"`
+	ListInfo   `json:"-"`
+}
+
+/*
+An operation over the entry of the movie. Status, score and favorite are the values
+of the added movie or the fields to update; version is only checked by updates
+*/
+type BatchOperation struct {
+	Op         string   `json:"op" enums:"add,update,delete"`
+	MovieID    int64    `json:"movie_id"`
+	Status     *string  `json:"status,omitempty"`
+	Score      *float64 `json:"score,omitempty"`
+	IsFavorite *bool    `json:"is_favorite,omitempty"`
+	Version    *int64   `json:"version,omitempty"`
+	/* the added or deleted entry, or the entry before the update */
+	Movie *ListUnit      `json:"-"`
+	Patch *ListUnitPatch `json:"-"`
+	/* the reason the operation isn't applied */
+	Err error `json:"-"`
+}
+
+/* Result of the operation with the same index */
+type BatchResult struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	MovieID int64  `json:"movie_id"`
+	Applied bool   `json:"applied"`
+	/* the new version of the added or updated entry */
+	Version *int64 `json:"version,omitempty"`
+	Code    int    `json:"code,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Err     error  `json:"-"`
+}
+
+/* If an atomic batch is rolled back, none of the operations are applied and only the failed ones have errors */
+type BatchReport struct {
+	Applied    int            `json:"applied"`
+	Failed     int            `json:"failed"`
+	RolledBack bool           `json:"rolled_back"`
+	Results    []*BatchResult `json:"results"`
+}
+
+func (b *Batch) Validate() error {
+	if len(b.Operations) == 0 {
+		return fmt.Errorf("there are no operations")
+	}
+	if len(b.Operations) > maxBatchOperations {
+		return fmt.Errorf("batch mustn't exceed %d operations", maxBatchOperations)
+	}
+	for i, op := range b.Operations {
+		if op == nil {
+			return fmt.Errorf("operation %d is empty", i)
+		}
+		switch op.Op {
+		case BatchAdd, BatchUpdate, BatchDelete:
+		default:
+			return fmt.Errorf("operation %d: unknown operation %q", i, op.Op)
+		}
+		if op.MovieID <= 0 {
+			return fmt.Errorf("operation %d: movie id must be specified", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package model
+
+import (
+	"sort"
+)
+
+/* A franchise: the listed movies connected by sequels and prequels, and their parts that aren't listed */
+type Collection struct {
+	ID      int64             `json:"id"` // the least Kinopoisk ID of the parts
+	Name    string            `json:"name"`
+	Parts   []*CollectionPart `json:"parts"`
+	Watched int               `json:"watched"`
+	Total   int               `json:"total"`
+}
+
+type CollectionPart struct {
+	Movie
+	Status  string `json:"status,omitempty"` // empty if the part isn't in the list
+	Watched bool   `json:"watched"`
+	Trashed bool   `json:"trashed,omitempty"` // the entry of the part is in the trash, it can be restored
+}
+
+/* A sequel or a prequel of the listed movie */
+type Sequel struct {
+	MovieID int64
+	Part    Movie
+}
+
+type CollectionPlan struct {
+	MovieID int64 // any part of the collection
+	ListInfo
+}
+
+/* union-find of movie IDs, the root of a set is its least ID */
+type disjointSet map[int64]int64
+
+func (s disjointSet) find(id int64) int64 {
+	parent, ok := s[id]
+	if !ok {
+		s[id] = id
+		return id
+	}
+	if parent == id {
+		return id
+	}
+	root := s.find(parent)
+	s[id] = root
+	return root
+}
+
+func (s disjointSet) union(a, b int64) {
+	a, b = s.find(a), s.find(b)
+	if a == b {
+		return
+	}
+	if b < a {
+		a, b = b, a
+	}
+	s[b] = a
+}
+
+/*
+Group the listed movies into collections by their sequels and prequels. Parts of a franchise
+are usually listed in every part of it on Kinopoisk, so the sequels of the listed movies are
+enough to join them; the movies without sequels don't make a collection
+*/
+func GroupCollections(entries []*ListUnit, sequels []*Sequel) []*Collection {
+	var (
+		set   = make(disjointSet)
+		parts = make(map[int64]*CollectionPart)
+	)
+	for _, sequel := range sequels {
+		set.union(sequel.MovieID, sequel.Part.ID)
+		if _, ok := parts[sequel.Part.ID]; !ok {
+			parts[sequel.Part.ID] = &CollectionPart{Movie: sequel.Part}
+		}
+	}
+	for _, entry := range entries {
+		if entry.DeletedAt != nil {
+			parts[entry.ID] = &CollectionPart{Movie: entry.Movie, Status: entry.Status, Trashed: true}
+			continue
+		}
+		parts[entry.ID] = &CollectionPart{
+			Movie:   entry.Movie,
+			Status:  entry.Status,
+			Watched: entry.Status == "completed",
+		}
+	}
+	var (
+		collections = make([]*Collection, 0)
+		byRoot      = make(map[int64]*Collection)
+	)
+	for id := range set {
+		root := set.find(id)
+		collection, ok := byRoot[root]
+		if !ok {
+			collection = &Collection{ID: root}
+			byRoot[root] = collection
+			collections = append(collections, collection)
+		}
+		part := parts[id]
+		if part == nil {
+			/* the listed movie whose entry is gone, e.g. it's been trashed meanwhile */
+			part = &CollectionPart{Movie: Movie{ID: id}}
+		}
+		collection.Parts = append(collection.Parts, part)
+		collection.Total++
+		if part.Watched {
+			collection.Watched++
+		}
+	}
+	for _, collection := range collections {
+		sortCollectionParts(collection.Parts)
+		collection.Name = collection.Parts[0].Name
+	}
+	sort.Slice(collections, func(i, j int) bool {
+		if collections[i].Name != collections[j].Name {
+			return collections[i].Name < collections[j].Name
+		}
+		return collections[i].ID < collections[j].ID
+	})
+	return collections
+}
+
+/* in order of release, the parts of unknown year go last */
+func sortCollectionParts(parts []*CollectionPart) {
+	sort.Slice(parts, func(i, j int) bool {
+		a, b := parts[i], parts[j]
+		if (a.Year == 0) != (b.Year == 0) {
+			return a.Year != 0
+		}
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		return a.ID < b.ID
+	})
+}
+
+/* Parts of the collection that aren't in the list yet, the trashed ones are in the list still */
+func (c *Collection) Missing() []*CollectionPart {
+	missing := make([]*CollectionPart, 0)
+	for _, part := range c.Parts {
+		if part.Status == "" {
+			missing = append(missing, part)
+		}
+	}
+	return missing
+}
+
+/* The collection containing the movie, nil if there's none */
+func FindCollection(collections []*Collection, movieID int64) *Collection {
+	for _, collection := range collections {
+		for _, part := range collection.Parts {
+			if part.ID == movieID {
+				return collection
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupCollections(t *testing.T) {
+	var (
+		deletedAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		entries   = []*ListUnit{
+			{Movie: Movie{ID: 301, Name: "Матрица", Year: 1999}, Status: "completed"},
+			{Movie: Movie{ID: 4220, Name: "Матрица: Революция", Year: 2003}, Status: "plan to watch"},
+			{Movie: Movie{ID: 409424, Name: "Дюна", Year: 2021}, Status: "completed"},
+			{Movie: Movie{ID: 326, Name: "Побег из Шоушенка", Year: 1994}, Status: "completed"},
+			{Movie: Movie{ID: 299, Name: "Матрица: Перезагрузка", Year: 2003}, Status: "completed", DeletedAt: &deletedAt},
+		}
+		sequels = []*Sequel{
+			{MovieID: 301, Part: Movie{ID: 4220, Name: "Матрица: Революция"}},
+			{MovieID: 301, Part: Movie{ID: 1309325, Name: "Матрица: Воскрешение", Year: 2021}},
+			{MovieID: 4220, Part: Movie{ID: 299, Name: "Матрица: Перезагрузка", Year: 2003}},
+			{MovieID: 409424, Part: Movie{ID: 4540126, Name: "Дюна: Часть вторая"}},
+		}
+	)
+	collections := GroupCollections(entries, sequels)
+	assert.Len(t, collections, 2)
+
+	dune := collections[0]
+	assert.Equal(t, int64(409424), dune.ID)
+	assert.Equal(t, "Дюна", dune.Name)
+	assert.Equal(t, 1, dune.Watched)
+	assert.Equal(t, 2, dune.Total)
+
+	matrix := collections[1]
+	assert.Equal(t, int64(299), matrix.ID)
+	assert.Equal(t, "Матрица", matrix.Name)
+	assert.Equal(t, 1, matrix.Watched)
+	assert.Equal(t, 4, matrix.Total)
+	ids := make([]int64, 0, len(matrix.Parts))
+	for _, part := range matrix.Parts {
+		ids = append(ids, part.ID)
+	}
+	/* in order of release, the parts of the same year by ID */
+	assert.Equal(t, []int64{301, 299, 4220, 1309325}, ids)
+	assert.Equal(t, "plan to watch", matrix.Parts[2].Status)
+
+	/* the trashed part isn't watched and isn't missing */
+	assert.True(t, matrix.Parts[1].Trashed)
+	assert.False(t, matrix.Parts[1].Watched)
+
+	missing := matrix.Missing()
+	assert.Len(t, missing, 1)
+	assert.Equal(t, int64(1309325), missing[0].ID)
+
+	assert.Equal(t, matrix, FindCollection(collections, 1309325))
+	assert.Nil(t, FindCollection(collections, 326))
+}
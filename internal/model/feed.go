@@ -0,0 +1,54 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ActivityAdded     = "added"
+	ActivityCompleted = "completed"
+	ActivityScored    = "scored"
+	ActivityFavorited = "favorited"
+	ActivityReviewed  = "reviewed"
+)
+
+/* An event of the user's list, that is shown in the feed of their followers */
+type Activity struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"-"`
+	Username  string    `json:"username"`
+	Kind      string    `json:"kind" enums:"added,completed,scored,favorited,reviewed"`
+	Movie     Movie     `json:"movie"`
+	Score     float64   `json:"score,omitempty"`
+	RawScore  uint8     `json:"-"`
+	ListID    int64     `json:"-"`
+	ListSlug  string    `json:"list_slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FeedQuery struct {
+	UserID int64
+	Cursor string
+	Limit  int
+}
+
+type Feed struct {
+	Activities []*Activity `json:"activities"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+type FollowedUser struct {
+	Username string    `json:"username"`
+	Since    time.Time `json:"since"`
+}
+
+func (q *FeedQuery) Validate() error {
+	if q.Limit == 0 {
+		q.Limit = defaultPageSize
+	}
+	if q.Limit < 0 || q.Limit > maxPageSize {
+		return fmt.Errorf("limit must be from 1 to %d", maxPageSize)
+	}
+	return nil
+}
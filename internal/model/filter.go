@@ -0,0 +1,85 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 500
+)
+
+/*
+the default order (no sort specified) is favorites first, then by score,
+then in the manual order; "rank" is the manual order only
+*/
+var listSorts = [...]string{"score", "added", "name", "year", "rank"}
+
+type ListFilter struct {
+	ListInfo
+	Status     string
+	IsFavorite *bool
+	/* scores on the user's scale and their bounds on the 0-100 scale */
+	MinScore    *float64
+	MaxScore    *float64
+	MinRawScore *uint8
+	MaxRawScore *uint8
+	Genre       string
+	Tags        []string
+	Year        int
+	Sort        string
+	Order       string
+	Cursor      string
+	Limit       int
+}
+
+type ListPage struct {
+	Movies     []*ListUnit `json:"movies"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+func (f *ListFilter) Validate() error {
+	/* the status is checked against the user's statuses */
+	f.Status = strings.TrimSpace(f.Status)
+	if (f.MinScore != nil && *f.MinScore < 0) || (f.MaxScore != nil && *f.MaxScore < 0) {
+		return fmt.Errorf("score cannot be negative")
+	}
+	if f.MinScore != nil && f.MaxScore != nil && *f.MinScore > *f.MaxScore {
+		return fmt.Errorf("min score cannot be greater than max score")
+	}
+	f.Genre = strings.ToLower(strings.TrimSpace(f.Genre))
+	for i, tag := range f.Tags {
+		f.Tags[i] = strings.ToLower(strings.TrimSpace(tag))
+	}
+	if f.Year < 0 {
+		return fmt.Errorf("invalid year %d", f.Year)
+	}
+	if err := f.validateSort(); err != nil {
+		return err
+	}
+	if f.Limit == 0 {
+		f.Limit = defaultPageSize
+	}
+	if f.Limit < 0 || f.Limit > maxPageSize {
+		return fmt.Errorf("limit must be from 1 to %d", maxPageSize)
+	}
+	return nil
+}
+
+func (f *ListFilter) validateSort() error {
+	f.Order = strings.ToLower(f.Order)
+	if f.Order != "" && f.Order != "asc" && f.Order != "desc" {
+		return fmt.Errorf("order must be either asc or desc")
+	}
+	if f.Sort == "" {
+		return nil
+	}
+	for _, sort := range listSorts {
+		if strings.EqualFold(sort, f.Sort) {
+			f.Sort = sort
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid sort, it must be one of %s", strings.Join(listSorts[:], ", "))
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+/* Change of the status or score of the list entry, the first one is adding to the list */
+type HistoryEntry struct {
+	ID        int64     `json:"id"`
+	MovieID   int64     `json:"movie_id"`
+	OldStatus *string   `json:"old_status,omitempty"`
+	Status    string    `json:"status"`
+	OldScore  *float64  `json:"old_score,omitempty"`
+	Score     float64   `json:"score"`
+	ChangedAt time.Time `json:"changed_at"`
+	/* the scores on the 0-100 scale */
+	OldRawScore *uint8 `json:"-"`
+	RawScore    uint8  `json:"-"`
+}
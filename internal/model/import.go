@@ -0,0 +1,176 @@
+package model
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+rows are matched in the background, a batch at a time, so the limit only keeps
+the uploaded file within reason; it's well above years of watch history
+*/
+const maxImportRows = 20000
+
+/* formats of the CSV files exported from Letterboxd and IMDb */
+const (
+	ImportLetterboxdWatched   = "letterboxd_watched"
+	ImportLetterboxdRatings   = "letterboxd_ratings"
+	ImportLetterboxdWatchlist = "letterboxd_watchlist"
+	ImportIMDbRatings         = "imdb_ratings"
+)
+
+type Import struct {
+	ID int64
+	ListInfo
+	Format string
+	Rows   []*ImportRow
+}
+
+/* The import that is processed in the background, the report has the rows processed so far */
+type ImportProgress struct {
+	ID         int64        `json:"id"`
+	Format     string       `json:"format"`
+	Total      int          `json:"total"`
+	Processed  int          `json:"processed"`
+	CreatedAt  time.Time    `json:"created_at"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+	Rows       []*ImportRow `json:"-"`
+	ImportReport
+}
+
+/*
+A row of the imported file. Matched rows have the movie, ambiguous ones have
+the candidates to choose from, failed ones have the reason
+*/
+type ImportRow struct {
+	Line       int     `json:"line"`
+	Title      string  `json:"title"`
+	Year       int     `json:"year,omitempty"`
+	IMDbID     string  `json:"imdb_id,omitempty"`
+	Status     string  `json:"status"`
+	Score      float64 `json:"score"`
+	RawScore   uint8   `json:"-"`
+	Movie      *Movie  `json:"movie,omitempty"`
+	Candidates []Movie `json:"candidates,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	Matched   []*ImportRow `json:"matched"`
+	Ambiguous []*ImportRow `json:"ambiguous"`
+	Failed    []*ImportRow `json:"failed"`
+}
+
+type importColumns struct {
+	title, year, imdbID, score string
+	status                     string
+	/* letterboxd scores are 0.5-5 stars */
+	stars bool
+}
+
+var importFormats = map[string]importColumns{
+	ImportLetterboxdWatched:   {title: "Name", year: "Year", status: "completed"},
+	ImportLetterboxdRatings:   {title: "Name", year: "Year", score: "Rating", status: "completed", stars: true},
+	ImportLetterboxdWatchlist: {title: "Name", year: "Year", status: "plan to watch"},
+	ImportIMDbRatings:         {title: "Title", year: "Year", imdbID: "Const", score: "Your Rating", status: "completed"},
+}
+
+func NewImportReport() *ImportReport {
+	return &ImportReport{
+		Matched:   make([]*ImportRow, 0),
+		Ambiguous: make([]*ImportRow, 0),
+		Failed:    make([]*ImportRow, 0),
+	}
+}
+
+/* The row goes to matched, ambiguous or failed ones by its result */
+func (r *ImportReport) Add(row *ImportRow) {
+	switch {
+	case row.Error != "":
+		r.Failed = append(r.Failed, row)
+	case row.Movie == nil:
+		r.Ambiguous = append(r.Ambiguous, row)
+	default:
+		r.Matched = append(r.Matched, row)
+	}
+}
+
+/* Read the rows of the CSV file of the format, the columns are found by the header */
+func ReadImportRows(format string, r io.Reader) ([]*ImportRow, error) {
+	columns, ok := importFormats[format]
+	if !ok {
+		return nil, fmt.Errorf("invalid format, it must be one of %s, %s, %s, %s", ImportLetterboxdWatched,
+			ImportLetterboxdRatings, ImportLetterboxdWatchlist, ImportIMDbRatings)
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %s", err)
+	}
+	/* the header may start with the byte order mark */
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")] = i
+	}
+	for _, name := range []string{columns.title, columns.year, columns.imdbID, columns.score} {
+		if _, ok := index[name]; name != "" && !ok {
+			return nil, fmt.Errorf("there's no %q column in the file", name)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := index[name]; ok && name != "" && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	rows := make([]*ImportRow, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %s", err)
+		}
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("file mustn't have more than %d rows", maxImportRows)
+		}
+		row := &ImportRow{
+			Line:   line,
+			Title:  field(record, columns.title),
+			IMDbID: field(record, columns.imdbID),
+			Status: columns.status,
+		}
+		row.Year, _ = strconv.Atoi(field(record, columns.year))
+		if row.RawScore, err = parseImportScore(field(record, columns.score), columns.stars); err != nil {
+			row.Error = err.Error()
+		}
+		if row.Title == "" && row.IMDbID == "" {
+			row.Error = "there's neither title nor IMDb ID"
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+/* scores are converted to 0-100, where 0 means there's no score */
+func parseImportScore(value string, stars bool) (uint8, error) {
+	if value == "" {
+		return 0, nil
+	}
+	score, err := strconv.ParseFloat(value, 64)
+	max := 10.0
+	if stars {
+		max = 5
+	}
+	if err != nil || score < 0 || score > max {
+		return 0, fmt.Errorf("invalid score %q", value)
+	}
+	return uint8(math.Round(score / max * maxRawScore)), nil
+}
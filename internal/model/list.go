@@ -3,30 +3,87 @@ package model
 import (
 	"fmt"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
-var titleStatus = [...]string{"watching", "completed", "on-hold", "dropped", "plan to watch"}
+const DefaultListName = "My list"
 
 type ListInfo struct {
 	ListID  int64 `json:"list_id"`
 	OwnerID int64 `json:"user_id"`
 }
 
+type List struct {
+	ID          int64     `json:"id"`
+	OwnerID     int64     `json:"user_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsDefault   bool      `json:"is_default"`
+	Visibility  string    `json:"visibility" example:"private"`
+	Slug        string    `json:"slug"`
+	CreatedOn   time.Time `json:"created_on"`
+}
+
+type ListPatch struct {
+	ID          *int64  `json:"-"`
+	OwnerID     *int64  `json:"-"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Visibility  *string `json:"visibility"`
+}
+
+type SearchQuery struct {
+	Query string
+	Page  int
+	Limit int
+	Year  int
+}
+
 type SearchResult struct {
-	Docs []Movie `json:"docs"`
+	Docs  []Movie `json:"docs"`
+	Total int     `json:"total,omitempty"` // unknown if the results are narrowed down by year
+	Limit int     `json:"limit"`
+	Page  int     `json:"page"`
+	Pages int     `json:"pages"`
 }
 
 type Movie struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID                int64      `json:"id"`
+	Name              string     `json:"name"`
+	AlternativeName   string     `json:"alternative_name,omitempty"`
+	EnName            string     `json:"en_name,omitempty"`
+	Type              string     `json:"type,omitempty" example:"movie" enums:"movie,tv-series,cartoon,anime,animated-series,tv-show"`
+	Year              int        `json:"year,omitempty"`
+	Genres            []string   `json:"genres,omitempty"`
+	Countries         []string   `json:"countries,omitempty"`
+	Duration          int        `json:"duration,omitempty"` // in minutes
+	AgeRating         int        `json:"age_rating,omitempty"`
+	PosterURL         string     `json:"poster_url,omitempty"`
+	KinopoiskRating   float64    `json:"kp_rating,omitempty"`
+	IMDbRating        float64    `json:"imdb_rating,omitempty"`
+	IMDbID            string     `json:"imdb_id,omitempty"`
+	IsSeries          bool       `json:"is_series,omitempty"`
+	EpisodesPerSeason []int64    `json:"episodes_per_season,omitempty"`
+	Premiere          *time.Time `json:"premiere,omitempty"`
+	DigitalRelease    *time.Time `json:"digital_release,omitempty"`
+	Sequels           []Movie    `json:"-"` // sequels and prequels, they're only saved to the cache
 }
 
 type ListUnit struct {
 	Movie
-	Status     string `json:"status"`
-	Score      uint8  `json:"score"`
-	IsFavorite bool   `json:"is_favorite"`
-	ListInfo   `json:"-"`
+	Status      string     `json:"status"`
+	Score       float64    `json:"score"`
+	RawScore    uint8      `json:"-"`
+	IsFavorite  bool       `json:"is_favorite"`
+	AddedAt     time.Time  `json:"added_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Progress    *Progress  `json:"progress,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Version     int64      `json:"version"`
+	ListInfo    `json:"-"`
 }
 
 /*
@@ -35,42 +92,105 @@ of partial update (PATCH HTTP method) of the list_titles table fields..
 so, I had to duplicate the code of the structure, but with pointers
 */
 type ListUnitPatch struct {
-	ListID     *int64  `json:"-"`
-	OwnerID    *int64  `json:"-"`
-	MovieID    *int64  `json:"-"`
-	Status     *string `json:"status"`
-	Score      *uint8  `json:"score"`
-	IsFavorite *bool   `json:"is_favorite"`
+	ListID     *int64   `json:"-"`
+	OwnerID    *int64   `json:"-"`
+	MovieID    *int64   `json:"-"`
+	Status     *string  `json:"status"`
+	Score      *float64 `json:"score"`
+	RawScore   *uint8   `json:"-"`
+	IsFavorite *bool    `json:"is_favorite"`
+	/* the expected version of the entry, any version matches if it's nil */
+	Version *int64 `json:"-"`
 }
 
-func (u *ListUnit) Validate() error {
-	if len(u.Name) == 0 {
-		return fmt.Errorf("empty movie name")
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+/* the entry has been changed since the client has got it */
+type PreconditionFailedError struct {
+	Message string
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return e.Message
+}
+
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+func (l *List) Validate() error {
+	l.Name = strings.TrimSpace(l.Name)
+	if l.Visibility == "" {
+		l.Visibility = VisibilityPrivate
 	}
-	if u.Score > 10 {
-		return fmt.Errorf("score cannot be greater than 10")
+	return validateListFields(&l.Name, &l.Description, &l.Visibility)
+}
+
+func (l *ListPatch) Validate() error {
+	if l.Name != nil {
+		*l.Name = strings.TrimSpace(*l.Name)
 	}
-	for _, status := range titleStatus {
-		if strings.EqualFold(status, u.Status) {
-			u.Status = status
-			return nil
+	return validateListFields(l.Name, l.Description, l.Visibility)
+}
+
+func validateListFields(name, description, visibility *string) error {
+	if name != nil {
+		length := utf8.RuneCountInString(*name)
+		if length == 0 || length > 100 {
+			return fmt.Errorf("list name must contain from 1 to 100 characters")
 		}
 	}
-	return fmt.Errorf("invalid title status")
+	if description != nil && utf8.RuneCountInString(*description) > 1000 {
+		return fmt.Errorf("list description mustn't exceed 1000 characters")
+	}
+	if visibility != nil {
+		return validateVisibility(*visibility)
+	}
+	return nil
 }
 
-func (u *ListUnitPatch) Validate() error {
-	if u.Score != nil && *u.Score > 10 {
-		return fmt.Errorf("score cannot be greater than 10")
+func (q *SearchQuery) Validate() error {
+	q.Query = strings.TrimSpace(q.Query)
+	if len(q.Query) == 0 {
+		return fmt.Errorf("empty search query")
 	}
-	if u.Status == nil {
-		return nil
+	if q.Page == 0 {
+		q.Page = 1
 	}
-	for _, status := range titleStatus {
-		if strings.EqualFold(status, *u.Status) {
-			*u.Status = status
-			return nil
-		}
+	if q.Limit == 0 {
+		q.Limit = 10
+	}
+	if q.Page < 0 || q.Limit < 0 || q.Limit > 50 {
+		return fmt.Errorf("page must be positive and limit must be from 1 to 50")
+	}
+	if q.Year < 0 {
+		return fmt.Errorf("invalid year %d", q.Year)
+	}
+	return nil
+}
+
+func (u *ListUnit) Validate() error {
+	if u.ID == 0 && len(u.Name) == 0 {
+		return fmt.Errorf("either movie id or movie name must be specified")
+	}
+	/* the score is checked against the user's scale and the status against the user's statuses */
+	u.Status = strings.TrimSpace(u.Status)
+	return nil
+}
+
+func (u *ListUnitPatch) Validate() error {
+	if u.Status == nil && u.Score == nil && u.IsFavorite == nil {
+		return fmt.Errorf("there's nothing to update")
 	}
-	return fmt.Errorf("invalid title status")
+	return nil
 }
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	NotificationPremiere       = "premiere"
+	NotificationDigitalRelease = "digital_release"
+)
+
+/* A reminder that the movie planned to watch has been released */
+type Notification struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"-"`
+	Kind        string    `json:"kind" enums:"premiere,digital_release"`
+	Movie       Movie     `json:"movie"`
+	ReleaseDate time.Time `json:"release_date"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+/* Subject and text of the email about the notification */
+func (n *Notification) Email() (string, string) {
+	var (
+		name = n.Movie.Name
+		date = n.ReleaseDate.Format("2 January 2006")
+	)
+	if name == "" {
+		name = fmt.Sprintf("Movie %d", n.Movie.ID)
+	}
+	if n.Kind == NotificationDigitalRelease {
+		return fmt.Sprintf("%q is out digitally", name),
+			fmt.Sprintf("%q from your plan to watch list has been released digitally on %s.", name, date)
+	}
+	return fmt.Sprintf("%q is out", name),
+		fmt.Sprintf("%q from your plan to watch list has premiered on %s.", name, date)
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+/*
+Private profiles and lists are seen by their owner only, unlisted ones
+are available by the link, public lists are also shown on the profile
+*/
+const (
+	VisibilityPrivate  = "private"
+	VisibilityUnlisted = "unlisted"
+	VisibilityPublic   = "public"
+)
+
+type Profile struct {
+	Username  string    `json:"username"`
+	CreatedOn time.Time `json:"created_on"`
+	Lists     []*List   `json:"lists"`
+}
+
+type PublicList struct {
+	List *List `json:"list"`
+	ListPage
+}
+
+type UserSettings struct {
+	ID                 int64   `json:"-"`
+	Visibility         *string `json:"visibility" example:"public"`
+	ScoreScale         *string `json:"score_scale" example:"five_stars" enums:"ten,five_stars,hundred"`
+	EmailNotifications *bool   `json:"email_notifications"` // email release reminders too
+}
+
+func (s *UserSettings) Validate() error {
+	if s.Visibility == nil && s.ScoreScale == nil && s.EmailNotifications == nil {
+		return fmt.Errorf("there's nothing to update")
+	}
+	if s.Visibility != nil {
+		if err := validateVisibility(*s.Visibility); err != nil {
+			return err
+		}
+	}
+	if s.ScoreScale != nil {
+		return validateScoreScale(*s.ScoreScale)
+	}
+	return nil
+}
+
+func validateVisibility(visibility string) error {
+	switch visibility {
+	case VisibilityPrivate, VisibilityUnlisted, VisibilityPublic:
+		return nil
+	}
+	return fmt.Errorf("visibility must be one of %s, %s, %s",
+		VisibilityPrivate, VisibilityUnlisted, VisibilityPublic)
+}
+
+/*
+Slug of the list for links, e.g. "7-horror-marathon". Only its ID part is
+used to find the list, so renaming the list doesn't break the old links
+*/
+func ListSlug(id int64, name string) string {
+	var (
+		slug strings.Builder
+		dash bool
+	)
+	for _, r := range strings.ToLower(name) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			dash = true
+			continue
+		}
+		if dash && slug.Len() > 0 {
+			slug.WriteByte('-')
+		}
+		dash = false
+		slug.WriteRune(r)
+	}
+	if slug.Len() == 0 {
+		return strconv.FormatInt(id, 10)
+	}
+	return strconv.FormatInt(id, 10) + "-" + slug.String()
+}
+
+func ListIDFromSlug(slug string) (int64, error) {
+	idStr, _, _ := strings.Cut(slug, "-")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, &NotFoundError{Message: fmt.Sprintf("list %q doesn't exist", slug)}
+	}
+	return id, nil
+}
@@ -0,0 +1,67 @@
+package model
+
+import "fmt"
+
+/* Progress of a series: the last watched episode of the current season */
+type Progress struct {
+	Season          int `json:"season"`
+	Episode         int `json:"episode"`
+	TotalSeasons    int `json:"total_seasons"`
+	SeasonEpisodes  int `json:"season_episodes"`
+	WatchedEpisodes int `json:"watched_episodes"`
+	TotalEpisodes   int `json:"total_episodes"`
+}
+
+type ProgressIncrement struct {
+	Episodes int `json:"episodes" example:"1"`
+}
+
+/* season and episode are 0 if the series hasn't been started yet */
+func NewProgress(season, episode int, episodesPerSeason []int64) *Progress {
+	p := &Progress{
+		Season:       season,
+		Episode:      episode,
+		TotalSeasons: len(episodesPerSeason),
+	}
+	for i, count := range episodesPerSeason {
+		p.TotalEpisodes += int(count)
+		if i+1 < season {
+			p.WatchedEpisodes += int(count)
+		}
+	}
+	p.WatchedEpisodes += episode
+	if season > 0 && season <= len(episodesPerSeason) {
+		p.SeasonEpisodes = int(episodesPerSeason[season-1])
+	}
+	return p
+}
+
+/* Move n episodes forward, moving on to the next seasons, but not past the last episode */
+func (p *Progress) Advance(n int, episodesPerSeason []int64) {
+	p.WatchedEpisodes += n
+	if p.WatchedEpisodes > p.TotalEpisodes {
+		p.WatchedEpisodes = p.TotalEpisodes
+	}
+	left := p.WatchedEpisodes
+	for i, count := range episodesPerSeason {
+		if left <= int(count) || i == len(episodesPerSeason)-1 {
+			*p = *NewProgress(i+1, left, episodesPerSeason)
+			return
+		}
+		left -= int(count)
+	}
+}
+
+func (p *Progress) IsFinished() bool {
+	return p.TotalEpisodes > 0 && p.WatchedEpisodes == p.TotalEpisodes
+}
+
+func (i *ProgressIncrement) Validate() error {
+	if i.Episodes == 0 {
+		i.Episodes = 1
+	}
+	if i.Episodes < 0 {
+		return fmt.Errorf("count of episodes must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const maxRankedMovies = 5000
+
+/* digits of the rank keys in ascending byte order */
+const rankDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+/* The new order of the list entries */
+type RankOrder struct {
+	IDs      []int64 `json:"ids"`
+	ListInfo `json:"-"`
+}
+
+/* Move of the entry right before or right after another one */
+type RankMove struct {
+	MovieID  int64  `json:"-"`
+	Before   *int64 `json:"before,omitempty"`
+	After    *int64 `json:"after,omitempty"`
+	ListInfo `json:"-"`
+}
+
+func (o *RankOrder) Validate() error {
+	if len(o.IDs) == 0 {
+		return fmt.Errorf("there are no movies to order")
+	}
+	if len(o.IDs) > maxRankedMovies {
+		return fmt.Errorf("count of ordered movies mustn't exceed %d", maxRankedMovies)
+	}
+	seen := make(map[int64]bool, len(o.IDs))
+	for _, id := range o.IDs {
+		if seen[id] {
+			return fmt.Errorf("movie %d is specified twice", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func (m *RankMove) Validate() error {
+	if (m.Before == nil) == (m.After == nil) {
+		return fmt.Errorf("either before or after must be specified")
+	}
+	if m.Target() == m.MovieID {
+		return fmt.Errorf("movie cannot be moved relative to itself")
+	}
+	return nil
+}
+
+/* the movie the entry is moved next to */
+func (m *RankMove) Target() int64 {
+	if m.After != nil {
+		return *m.After
+	}
+	return *m.Before
+}
+
+/*
+Key between the keys a and b, a < b. An empty a means the beginning of the list
+and an empty b means the end, so the new key is just before or just after the other one
+*/
+func RankBetween(a, b string) string {
+	switch {
+	case b == "":
+		return rankAfter(a)
+	case a == "":
+		return rankBefore(b)
+	}
+	return rankMidpoint(a, b)
+}
+
+/* n keys between a and b in ascending order, the range is split in halves to keep them short */
+func RanksBetween(a, b string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	mid := RankBetween(a, b)
+	ranks := append(RanksBetween(a, mid, n/2), mid)
+	return append(ranks, RanksBetween(mid, b, n-n/2-1)...)
+}
+
+/* The shortest key after a: its first digit that can be incremented is incremented */
+func rankAfter(a string) string {
+	for i := 0; i < len(a); i++ {
+		if digit := strings.IndexByte(rankDigits, a[i]); digit < len(rankDigits)-1 {
+			return a[:i] + string(rankDigits[digit+1])
+		}
+	}
+	return a + string(rankDigits[len(rankDigits)/2])
+}
+
+/* The shortest key before b, it mustn't end with "0" */
+func rankBefore(b string) string {
+	for i := 0; i < len(b); i++ {
+		if digit := strings.IndexByte(rankDigits, b[i]); digit > 1 {
+			return b[:i] + string(rankDigits[digit-1])
+		}
+	}
+	/* b consists of "0" and "1" and ends with "1" */
+	return b[:len(b)-1] + "0" + string(rankDigits[len(rankDigits)/2])
+}
+
+/* a is padded with "0" to compare it with b digit by digit */
+func rankMidpoint(a, b string) string {
+	if b != "" {
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + rankMidpoint(rest, b[n:])
+		}
+	}
+	low, high := 0, len(rankDigits)
+	if a != "" {
+		low = strings.IndexByte(rankDigits, a[0])
+	}
+	if b != "" {
+		high = strings.IndexByte(rankDigits, b[0])
+	}
+	if high-low > 1 {
+		return string(rankDigits[(low+high+1)/2])
+	}
+	/* the first digits are adjacent */
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(rankDigits[low]) + rankMidpoint(rest, "")
+}
+
+func rankDigitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return rankDigits[0]
+}
+
+/*
+Entries that must get new ranks to follow each other in the given order. The longest
+subsequence of the ranks that are already in order stays, so the least entries are changed
+*/
+func MisplacedRanks(ranks []string) []bool {
+	var (
+		/* tails[k] is the index of the least last rank of an ordered subsequence of length k+1 */
+		tails = make([]int, 0, len(ranks))
+		prev  = make([]int, len(ranks))
+	)
+	for i, rank := range ranks {
+		k := sort.Search(len(tails), func(k int) bool { return ranks[tails[k]] >= rank })
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+	misplaced := make([]bool, len(ranks))
+	for i := range misplaced {
+		misplaced[i] = true
+	}
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			misplaced[i] = false
+		}
+	}
+	return misplaced
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankBetween(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{name: "Empty list", a: "", b: "", expected: "V"},
+		{name: "First", a: "", b: "0000012V", expected: "0000011"},
+		{name: "Last", a: "0000012V", b: "", expected: "1"},
+		{name: "After last digit", a: "zz", b: "", expected: "zzV"},
+		{name: "Before smallest key", a: "", b: "01", expected: "00V"},
+		{name: "Between", a: "A", b: "C", expected: "B"},
+		{name: "Adjacent digits", a: "1", b: "2", expected: "1V"},
+		{name: "Common prefix", a: "0000012V", b: "0000013V", expected: "0000013"},
+		{name: "Shorter key", a: "1V", b: "2", expected: "1l"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rank := RankBetween(tc.a, tc.b)
+			assert.Equal(t, tc.expected, rank)
+			assert.True(t, tc.a < rank && (tc.b == "" || rank < tc.b))
+		})
+	}
+}
+
+func TestRankBetween_Repeated(t *testing.T) {
+	/* inserting at the same place again and again */
+	a, b := "A", "B"
+	for i := 0; i < 1000; i++ {
+		rank := RankBetween(a, b)
+		assert.True(t, a < rank && rank < b, "%q isn't between %q and %q", rank, a, b)
+		assert.False(t, strings.HasSuffix(rank, "0"))
+		if i%2 == 0 {
+			a = rank
+		} else {
+			b = rank
+		}
+	}
+}
+
+func TestRanksBetween(t *testing.T) {
+	for _, bounds := range [][2]string{{"", ""}, {"1", "2"}, {"", "01"}, {"zy", ""}} {
+		ranks := RanksBetween(bounds[0], bounds[1], 100)
+		assert.Len(t, ranks, 100)
+		prev := bounds[0]
+		for _, rank := range ranks {
+			assert.True(t, prev < rank, "%q isn't after %q", rank, prev)
+			assert.LessOrEqual(t, len(rank), len(bounds[0])+len(bounds[1])+4)
+			prev = rank
+		}
+		if bounds[1] != "" {
+			assert.Less(t, prev, bounds[1])
+		}
+	}
+}
+
+func TestMisplacedRanks(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ranks    []string
+		expected []bool
+	}{
+		{
+			name:     "Ordered",
+			ranks:    []string{"A", "B", "C"},
+			expected: []bool{false, false, false},
+		},
+		{
+			name:     "Moved to the top",
+			ranks:    []string{"D", "A", "B", "C"},
+			expected: []bool{true, false, false, false},
+		},
+		{
+			name:     "Swapped",
+			ranks:    []string{"A", "C", "B", "D"},
+			expected: []bool{false, true, false, false},
+		},
+		{
+			name:     "Reversed",
+			ranks:    []string{"C", "B", "A"},
+			expected: []bool{true, true, false},
+		},
+		{
+			name:     "Equal ranks",
+			ranks:    []string{"A", "A", "B"},
+			expected: []bool{true, false, false},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MisplacedRanks(tc.ranks))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package model
+
+import "fmt"
+
+const maxRecommendations = 100
+
+const (
+	/* the movie is similar to the ones the user has scored high or favorited */
+	RecommendationSimilar = "similar"
+	/* the movie is of the genres the user watches, or just popular one for new accounts */
+	RecommendationGenre = "genre"
+)
+
+type Recommendation struct {
+	Movie
+	Relevance float64 `json:"relevance"`
+	Source    string  `json:"source" enums:"similar,genre"`
+}
+
+type RecommendationQuery struct {
+	UserID int64
+	Limit  int
+}
+
+func (q *RecommendationQuery) Validate() error {
+	if q.Limit == 0 {
+		q.Limit = 20
+	}
+	if q.Limit < 0 || q.Limit > maxRecommendations {
+		return fmt.Errorf("limit must be from 1 to %d", maxRecommendations)
+	}
+	return nil
+}
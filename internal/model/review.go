@@ -0,0 +1,50 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+/* Review of the list entry written in markdown, HTML is rendered from the body */
+type Review struct {
+	MovieID     int64      `json:"movie_id"`
+	Author      string     `json:"author,omitempty"`
+	Body        string     `json:"body" example:"**Visually stunning**, but the second half drags"`
+	HTML        string     `json:"html"`
+	HasSpoilers bool       `json:"has_spoilers"`
+	IsPublic    bool       `json:"is_public"`
+	CreatedAt   time.Time  `json:"created_at"`
+	EditedAt    *time.Time `json:"edited_at,omitempty"`
+	ListInfo    `json:"-"`
+}
+
+type ReviewPatch struct {
+	MovieID     int64   `json:"-"`
+	Body        *string `json:"body"`
+	HasSpoilers *bool   `json:"has_spoilers"`
+	IsPublic    *bool   `json:"is_public"`
+	ListInfo    `json:"-"`
+}
+
+func (r *Review) Validate() error {
+	r.Body = strings.TrimSpace(r.Body)
+	return validateReviewBody(r.Body)
+}
+
+func (r *ReviewPatch) Validate() error {
+	if r.Body == nil {
+		return nil
+	}
+	*r.Body = strings.TrimSpace(*r.Body)
+	return validateReviewBody(*r.Body)
+}
+
+func validateReviewBody(body string) error {
+	length := utf8.RuneCountInString(body)
+	if length == 0 || length > 10000 {
+		return fmt.Errorf("review must contain from 1 to 10000 characters")
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+/*
+Scores are kept on the 0-100 scale, where 0 means there's no score. Users enter
+and see them on the scale of their choice, rounded to the step of the scale
+*/
+const maxRawScore = 100
+
+const (
+	ScaleTen       = "ten"
+	ScaleFiveStars = "five_stars"
+	ScaleHundred   = "hundred"
+)
+
+type scoreScale struct {
+	max, step float64
+}
+
+var scoreScales = map[string]scoreScale{
+	ScaleTen:       {max: 10, step: 1},
+	ScaleFiveStars: {max: 5, step: 0.5},
+	ScaleHundred:   {max: 100, step: 1},
+}
+
+func validateScoreScale(scale string) error {
+	if _, ok := scoreScales[scale]; !ok {
+		return fmt.Errorf("score scale must be one of %s, %s, %s", ScaleTen, ScaleFiveStars, ScaleHundred)
+	}
+	return nil
+}
+
+/* unknown scales fall back to 1-10 */
+func findScoreScale(scale string) scoreScale {
+	if s, ok := scoreScales[scale]; ok {
+		return s
+	}
+	return scoreScales[ScaleTen]
+}
+
+/* The score of the scale on the 0-100 scale, the score must be a multiple of the step */
+func RawScore(score float64, scale string) (uint8, error) {
+	s := findScoreScale(scale)
+	steps := score / s.step
+	if score < 0 || score > s.max || math.Abs(steps-math.Round(steps)) > 1e-9 {
+		return 0, fmt.Errorf("score must be from %s to %s in steps of %s, or 0 for no score",
+			FormatScore(s.step), FormatScore(s.max), FormatScore(s.step))
+	}
+	return uint8(math.Round(score / s.max * maxRawScore)), nil
+}
+
+/* The score rounded to the step of the scale, any score is at least one step */
+func ScaleScore(raw uint8, scale string) float64 {
+	if raw == 0 {
+		return 0
+	}
+	s := findScoreScale(scale)
+	return math.Max(math.Round(float64(raw)*s.max/maxRawScore/s.step)*s.step, s.step)
+}
+
+/* Mean of the raw scores on the scale, it isn't rounded to the step */
+func ScaleMeanScore(mean float64, scale string) float64 {
+	return math.Round(mean*findScoreScale(scale).max/maxRawScore*100) / 100
+}
+
+/* A score filter bound can't be above the max of the scale, the filter would match nothing */
+func ValidateScoreBound(score float64, scale string) error {
+	if max := findScoreScale(scale).max; score > max {
+		return fmt.Errorf("score cannot be greater than %s", FormatScore(max))
+	}
+	return nil
+}
+
+/* The least raw score that is shown as the score or a higher one */
+func MinRawScore(score float64, scale string) uint8 {
+	s := findScoreScale(scale)
+	if score <= 0 {
+		return 0
+	}
+	if score <= s.step {
+		return 1
+	}
+	return uint8(math.Min(math.Ceil((score-s.step/2)/s.max*maxRawScore-1e-9), maxRawScore))
+}
+
+/* The greatest raw score that is shown as the score or a lower one */
+func MaxRawScore(score float64, scale string) uint8 {
+	s := findScoreScale(scale)
+	if score <= 0 {
+		return 0
+	}
+	if score >= s.max {
+		return maxRawScore
+	}
+	return uint8(math.Max(math.Ceil((score+s.step/2)/s.max*maxRawScore-1e-9)-1, 1))
+}
+
+/* The score without trailing zeros, e.g. "3.5" or "8" */
+func FormatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawScore(t *testing.T) {
+	testCases := []struct {
+		name        string
+		score       float64
+		scale       string
+		expected    uint8
+		expectedErr bool
+	}{
+		{name: "No score", score: 0, scale: ScaleTen, expected: 0},
+		{name: "Ten", score: 8, scale: ScaleTen, expected: 80},
+		{name: "Half of a star", score: 3.5, scale: ScaleFiveStars, expected: 70},
+		{name: "Hundred", score: 73, scale: ScaleHundred, expected: 73},
+		{name: "Unknown scale", score: 7, scale: "", expected: 70},
+		{name: "Not a step", score: 7.5, scale: ScaleTen, expectedErr: true},
+		{name: "Quarter of a star", score: 3.25, scale: ScaleFiveStars, expectedErr: true},
+		{name: "Too high", score: 6, scale: ScaleFiveStars, expectedErr: true},
+		{name: "Negative", score: -1, scale: ScaleHundred, expectedErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := RawScore(tc.score, tc.scale)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, raw)
+		})
+	}
+}
+
+func TestScaleScore(t *testing.T) {
+	testCases := []struct {
+		name     string
+		raw      uint8
+		scale    string
+		expected float64
+	}{
+		{name: "No score", raw: 0, scale: ScaleFiveStars, expected: 0},
+		{name: "Ten", raw: 80, scale: ScaleTen, expected: 8},
+		{name: "Rounded up", raw: 85, scale: ScaleTen, expected: 9},
+		{name: "Rounded down", raw: 84, scale: ScaleTen, expected: 8},
+		{name: "Stars", raw: 73, scale: ScaleFiveStars, expected: 3.5},
+		{name: "At least one step", raw: 1, scale: ScaleFiveStars, expected: 0.5},
+		{name: "Hundred", raw: 73, scale: ScaleHundred, expected: 73},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ScaleScore(tc.raw, tc.scale))
+		})
+	}
+}
+
+func TestScoreBounds(t *testing.T) {
+	/* the bounds must match exactly the raw scores shown as the score */
+	for _, scale := range []string{ScaleTen, ScaleFiveStars, ScaleHundred} {
+		s := scoreScales[scale]
+		for score := s.step; score <= s.max; score += s.step {
+			min, max := MinRawScore(score, scale), MaxRawScore(score, scale)
+			for raw := 1; raw <= maxRawScore; raw++ {
+				shown := ScaleScore(uint8(raw), scale)
+				assert.Equal(t, shown >= score, uint8(raw) >= min, "%s: %d shown as %v, min %v", scale, raw, shown, score)
+				assert.Equal(t, shown <= score, uint8(raw) <= max, "%s: %d shown as %v, max %v", scale, raw, shown, score)
+			}
+		}
+	}
+}
+
+func TestValidateScoreBound(t *testing.T) {
+	assert.NoError(t, ValidateScoreBound(5, ScaleFiveStars))
+	assert.Error(t, ValidateScoreBound(8, ScaleFiveStars))
+	assert.NoError(t, ValidateScoreBound(10, ScaleTen))
+	assert.Error(t, ValidateScoreBound(10.5, ScaleTen))
+	assert.NoError(t, ValidateScoreBound(100, ScaleHundred))
+}
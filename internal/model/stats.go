@@ -0,0 +1,23 @@
+package model
+
+/* Statistics of the list, trashed movies aren't counted */
+type Stats struct {
+	Total             int            `json:"total"`
+	ByStatus          map[string]int `json:"by_status"`
+	Favorites         int            `json:"favorites"`
+	MeanScore         float64        `json:"mean_score"`
+	ScoreDistribution map[string]int `json:"score_distribution"`
+	/* counts of the scores on the 0-100 scale */
+	RawScores map[uint8]int  `json:"-"`
+	Genres    []*StatsBucket `json:"genres"`
+	Decades   []*StatsBucket `json:"decades"`
+	Countries []*StatsBucket `json:"countries"`
+	WatchTime int            `json:"watch_time"`
+}
+
+/* Count of movies of the genre, decade, etc. and their mean score */
+type StatsBucket struct {
+	Name      string  `json:"name"`
+	Count     int     `json:"count"`
+	MeanScore float64 `json:"mean_score"`
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+Every user starts with these statuses. Other features rely on them
+(e.g. a series becomes "watching" and then "completed"), so they can be
+reordered, but neither renamed nor removed
+*/
+var DefaultStatuses = [...]string{"watching", "completed", "on-hold", "dropped", "plan to watch"}
+
+/* Status of list entries, e.g. "rewatching" or "waiting for next season" */
+type Status struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name" example:"rewatching"`
+	Position  int    `json:"position"`
+	IsBuiltin bool   `json:"is_builtin"`
+	OwnerID   int64  `json:"-"`
+}
+
+/* The new order of all the user's statuses */
+type StatusOrder struct {
+	IDs     []int64 `json:"ids"`
+	OwnerID int64   `json:"-"`
+}
+
+/* Removal of the status, its entries get the target status */
+type StatusRemoval struct {
+	ID       int64
+	TargetID int64
+	OwnerID  int64
+}
+
+/* status names are case insensitive, so they're kept in lower case */
+func (s *Status) Validate() error {
+	s.Name = strings.ToLower(strings.TrimSpace(s.Name))
+	length := utf8.RuneCountInString(s.Name)
+	if length == 0 || length > 30 {
+		return fmt.Errorf("status name must contain from 1 to 30 characters")
+	}
+	return nil
+}
+
+func (o *StatusOrder) Validate() error {
+	seen := make(map[int64]bool, len(o.IDs))
+	for _, id := range o.IDs {
+		if seen[id] {
+			return fmt.Errorf("status %d is specified twice", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func (r *StatusRemoval) Validate() error {
+	if r.TargetID == 0 {
+		return fmt.Errorf("status to move the movies to isn't specified")
+	}
+	if r.ID == r.TargetID {
+		return fmt.Errorf("movies cannot be moved to the removed status")
+	}
+	return nil
+}
+
+/* The user's status with the same name, statuses are matched case insensitively */
+func NormalizeStatus(name string, statuses []*Status) (string, error) {
+	name = strings.TrimSpace(name)
+	for _, status := range statuses {
+		if strings.EqualFold(status.Name, name) {
+			return status.Name, nil
+		}
+	}
+	return "", fmt.Errorf("invalid title status")
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+/* User's label of list entries, e.g. "rewatch-worthy" or "cinema" */
+type Tag struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name" example:"rewatch-worthy"`
+	Count   int    `json:"count"`
+	OwnerID int64  `json:"-"`
+}
+
+/* Merge of the source tag into the target one, the source tag is deleted */
+type TagMerge struct {
+	SourceID int64 `json:"-"`
+	TargetID int64 `json:"into"`
+	OwnerID  int64 `json:"-"`
+}
+
+/* tag names are case insensitive, so they're kept in lower case */
+func (t *Tag) Validate() error {
+	t.Name = strings.ToLower(strings.TrimSpace(t.Name))
+	length := utf8.RuneCountInString(t.Name)
+	if length == 0 || length > 50 {
+		return fmt.Errorf("tag name must contain from 1 to 50 characters")
+	}
+	return nil
+}
+
+func (m *TagMerge) Validate() error {
+	if m.TargetID == 0 {
+		return fmt.Errorf("target tag isn't specified")
+	}
+	if m.SourceID == m.TargetID {
+		return fmt.Errorf("tag cannot be merged into itself")
+	}
+	return nil
+}
@@ -5,10 +5,13 @@ import (
 )
 
 type User struct {
-	ID             int64     `json:"id"`
-	Username       string    `json:"username"`
-	Email          string    `json:"email"`
-	HashedPassword string    `json:"-"`
-	CreatedOn      time.Time `json:"created_on"`
-	LastLogin      time.Time `json:"last_login"`
+	ID                 int64     `json:"id"`
+	Username           string    `json:"username"`
+	Email              string    `json:"email"`
+	HashedPassword     string    `json:"-"`
+	CreatedOn          time.Time `json:"created_on"`
+	LastLogin          time.Time `json:"last_login"`
+	Visibility         string    `json:"visibility"`
+	ScoreScale         string    `json:"score_scale"`
+	EmailNotifications bool      `json:"email_notifications"`
 }
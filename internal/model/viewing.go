@@ -0,0 +1,39 @@
+package model
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+const dateLayout = "2006-01-02"
+
+type Viewing struct {
+	ID        int64     `json:"id"`
+	MovieID   int64     `json:"movie_id"`
+	WatchedOn string    `json:"watched_on" example:"2023-07-01"`
+	Score     *float64  `json:"score,omitempty"`
+	RawScore  *uint8    `json:"-"`
+	IsRewatch bool      `json:"is_rewatch"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	ListInfo  `json:"-"`
+}
+
+func (v *Viewing) Validate() error {
+	if v.WatchedOn == "" {
+		v.WatchedOn = time.Now().Format(dateLayout)
+	}
+	watchedOn, err := time.Parse(dateLayout, v.WatchedOn)
+	if err != nil {
+		return fmt.Errorf("watch date must be in YYYY-MM-DD format")
+	}
+	/* a day ahead because of time zones */
+	if watchedOn.After(time.Now().AddDate(0, 0, 1)) {
+		return fmt.Errorf("watch date cannot be in the future")
+	}
+	if utf8.RuneCountInString(v.Note) > 2000 {
+		return fmt.Errorf("note mustn't exceed 2000 characters")
+	}
+	return nil
+}
@@ -18,10 +18,11 @@ const (
 )
 
 type authService struct {
-	user  UserRepository
-	token TokenRepository
-	list  ListRepository
-	cfg   *config.Config
+	user   UserRepository
+	token  TokenRepository
+	list   ListRepository
+	status StatusRepository
+	cfg    *config.Config
 }
 
 type UserRepository interface {
@@ -29,6 +30,8 @@ type UserRepository interface {
 	FindByEmail(context.Context, string) (*model.User, error)
 	UpdateLastLogin(context.Context, *model.User) error
 	FindByID(context.Context, int64) (*model.User, error)
+	FindByUsername(context.Context, string) (*model.User, error)
+	UpdateSettings(context.Context, *model.UserSettings) error
 	DeleteAccount(context.Context, int64) error
 }
 
@@ -38,8 +41,12 @@ type TokenRepository interface {
 }
 
 type ListRepository interface {
-	Create(context.Context, int64) (*model.ListInfo, error)
-	GetID(context.Context, int64) (int64, error)
+	Create(context.Context, *model.List) error
+	GetDefaultID(context.Context, int64) (int64, error)
+	GetByID(context.Context, int64, int64) (*model.List, error)
+	GetAll(context.Context, int64) ([]*model.List, error)
+	Update(context.Context, *model.ListPatch) error
+	Delete(context.Context, int64, int64) error
 }
 
 func (s *authService) SignUp(userDTO *model.SignUpUserDTO) (*model.ListInfo, error) {
@@ -62,7 +69,19 @@ func (s *authService) SignUp(userDTO *model.SignUpUserDTO) (*model.ListInfo, err
 	if err := s.user.CreateAccount(ctx, user); err != nil {
 		return nil, err
 	}
-	return s.list.Create(ctx, user.ID)
+	list := &model.List{
+		OwnerID:    user.ID,
+		Name:       model.DefaultListName,
+		IsDefault:  true,
+		Visibility: model.VisibilityPrivate,
+	}
+	if err := s.list.Create(ctx, list); err != nil {
+		return nil, err
+	}
+	if err := s.status.CreateDefaults(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return &model.ListInfo{ListID: list.ID, OwnerID: list.OwnerID}, nil
 }
 
 func (s *authService) SignIn(userDTO *model.SignInUserDTO) (*model.Tokens, error) {
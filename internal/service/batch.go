@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+/*
+Apply the operations to the list in one transaction. The operations are checked and prepared
+one by one first (e.g. the added movies are fetched from Kinopoisk), so a failed operation
+doesn't stop the others unless the batch is atomic
+*/
+func (s *listService) BatchMovies(batch *model.Batch) (*model.BatchReport, error) {
+	if err := batch.Validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	err := resolveList(ctx, s.list, &batch.ListInfo)
+	var (
+		statuses []*model.Status
+		scale    string
+	)
+	if err == nil {
+		statuses, err = s.status.GetAll(ctx, batch.OwnerID)
+	}
+	if err == nil {
+		scale, err = userScoreScale(ctx, s.user, batch.OwnerID)
+	}
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	failed := false
+	for _, op := range batch.Operations {
+		op.Err = s.prepareOperation(&batch.ListInfo, statuses, scale, op)
+		failed = failed || op.Err != nil
+	}
+	if !failed || !batch.Atomic {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := s.movie.Batch(ctx, batch)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.batchReport(batch), nil
+}
+
+func (s *listService) prepareOperation(list *model.ListInfo, statuses []*model.Status, scale string,
+	op *model.BatchOperation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	op.Movie = &model.ListUnit{Movie: model.Movie{ID: op.MovieID}, ListInfo: *list}
+	switch op.Op {
+	case model.BatchAdd:
+		movie := op.Movie
+		if op.Status != nil {
+			movie.Status = *op.Status
+		}
+		if op.Score != nil {
+			raw, err := model.RawScore(*op.Score, scale)
+			if err != nil {
+				return err
+			}
+			movie.RawScore = raw
+		}
+		if op.IsFavorite != nil {
+			movie.IsFavorite = *op.IsFavorite
+		}
+		if err := movie.Validate(); err != nil {
+			return err
+		}
+		status, err := model.NormalizeStatus(movie.Status, statuses)
+		if err != nil {
+			return err
+		}
+		movie.Status = status
+		return s.fillFromKinopoisk(ctx, &movie.Movie)
+	case model.BatchUpdate:
+		op.Patch = &model.ListUnitPatch{
+			ListID:     &op.Movie.ListID,
+			OwnerID:    &op.Movie.OwnerID,
+			MovieID:    &op.Movie.ID,
+			Status:     op.Status,
+			Score:      op.Score,
+			IsFavorite: op.IsFavorite,
+			Version:    op.Version,
+		}
+		if err := op.Patch.Validate(); err != nil {
+			return err
+		}
+		if op.Status != nil {
+			status, err := model.NormalizeStatus(*op.Status, statuses)
+			if err != nil {
+				return err
+			}
+			op.Patch.Status = &status
+		}
+		if op.Score != nil {
+			raw, err := model.RawScore(*op.Score, scale)
+			if err != nil {
+				return err
+			}
+			op.Patch.RawScore = &raw
+		}
+		/* the entry itself is read when the batch is applied, it may be added by the batch */
+	}
+	return nil
+}
+
+/* The applied operations are recorded to the feed */
+func (s *listService) batchReport(batch *model.Batch) *model.BatchReport {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	report := &model.BatchReport{Results: make([]*model.BatchResult, 0, len(batch.Operations))}
+	for _, op := range batch.Operations {
+		if op.Err != nil && batch.Atomic {
+			report.RolledBack = true
+		}
+	}
+	for i, op := range batch.Operations {
+		result := &model.BatchResult{Index: i, Op: op.Op, MovieID: op.MovieID, Err: op.Err}
+		report.Results = append(report.Results, result)
+		if op.Err != nil {
+			report.Failed++
+			continue
+		}
+		if report.RolledBack {
+			continue
+		}
+		result.Applied = true
+		report.Applied++
+		switch op.Op {
+		case model.BatchAdd:
+			result.Version = &op.Movie.Version
+			recordActivity(ctx, s.activity, model.ActivityAdded, op.Movie)
+		case model.BatchUpdate:
+			result.Version = op.Patch.Version
+			s.recordUpdate(ctx, op.Movie, op.Patch)
+		}
+	}
+	return report
+}
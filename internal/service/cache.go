@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/config"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type movieCacheRefresher struct {
+	searcher MovieSearcher
+	cache    MovieCacheRepository
+	cfg      *config.MoviesCacheConfig
+}
+
+type MovieCacheRepository interface {
+	Save(context.Context, *model.Movie) error
+	MarkFailed(context.Context, int64) error
+	GetOutdated(context.Context, time.Time, int) ([]int64, error)
+	GetUnreleased(context.Context, time.Time, int) ([]int64, error)
+	FindByIMDbID(context.Context, string) (*model.Movie, error)
+}
+
+func (r *movieCacheRefresher) Run(ctx context.Context) {
+	runPeriodically(ctx, r.cfg.RefreshInterval, r.refresh)
+}
+
+/*
+Re-fetch a batch of listed movies whose cached info is older than TTL (or absent at all).
+A movie that fails is skipped and marked, so that the next batches start with the others
+*/
+func (r *movieCacheRefresher) refresh(ctx context.Context) error {
+	ids, err := r.cache.GetOutdated(ctx, time.Now().Add(-r.cfg.TTL), r.cfg.RefreshBatchSize)
+	if err != nil {
+		return err
+	}
+	refreshed := 0
+	for _, id := range ids {
+		if err := refreshMovie(ctx, r.searcher, r.cache, id); err != nil {
+			log.Printf("movies cache: refresh movie %d: %s", id, err.Error())
+			continue
+		}
+		refreshed++
+	}
+	if refreshed > 0 {
+		log.Printf("movies cache: %d movies have been refreshed", refreshed)
+	}
+	return nil
+}
+
+/* the movie that fails is marked, a failure to mark it is only logged */
+func refreshMovie(ctx context.Context, searcher MovieSearcher, cache MovieCacheRepository, id int64) error {
+	movie, err := searcher.SearchByID(ctx, id)
+	if err == nil {
+		err = cache.Save(ctx, movie)
+	}
+	if err == nil {
+		return nil
+	}
+	if markErr := cache.MarkFailed(ctx, id); markErr != nil {
+		log.Printf("movies cache: mark movie %d: %s", id, markErr.Error())
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+/* Franchises of the list, joined by the sequels and prequels known from Kinopoisk */
+func (s *listService) GetCollections(list *model.ListInfo) ([]*model.Collection, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, list); err != nil {
+		return nil, err
+	}
+	return s.getCollections(ctx, list)
+}
+
+func (s *listService) getCollections(ctx context.Context, list *model.ListInfo) ([]*model.Collection, error) {
+	entries, err := s.movie.GetSequelEntries(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	sequels, err := s.movie.GetSequels(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	return model.GroupCollections(entries, sequels), nil
+}
+
+/*
+Add the parts of the collection that aren't in the list yet as planned to watch, one by one
+like AddMovie does. If a part fails, the ones added before stay, so the request can be repeated.
+The trashed parts aren't added, that would replace their entries along with the scores and history
+*/
+func (s *listService) PlanCollection(plan *model.CollectionPlan) ([]*model.ListUnit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	err := resolveList(ctx, s.list, &plan.ListInfo)
+	var collections []*model.Collection
+	if err == nil {
+		collections, err = s.getCollections(ctx, &plan.ListInfo)
+	}
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	collection := model.FindCollection(collections, plan.MovieID)
+	if collection == nil {
+		return nil, &model.NotFoundError{Message: fmt.Sprintf("movie %d isn't in any collection of the list", plan.MovieID)}
+	}
+	added := make([]*model.ListUnit, 0)
+	for _, part := range collection.Missing() {
+		movie := &model.ListUnit{
+			Movie:    model.Movie{ID: part.ID},
+			Status:   "plan to watch",
+			ListInfo: plan.ListInfo,
+		}
+		err := s.AddMovie(movie)
+		var conflictErr *model.ConflictError
+		if errors.As(err, &conflictErr) {
+			/* it's been added meanwhile */
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, movie)
+	}
+	return added, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type feedService struct {
+	follow   FollowRepository
+	activity ActivityRepository
+	user     UserRepository
+}
+
+type FollowRepository interface {
+	Follow(context.Context, int64, int64) error
+	Unfollow(context.Context, int64, int64) error
+	GetFollowing(context.Context, int64) ([]*model.FollowedUser, error)
+	GetFollowers(context.Context, int64) ([]*model.FollowedUser, error)
+}
+
+type ActivityRepository interface {
+	Add(context.Context, *model.Activity) error
+	GetFeed(context.Context, *model.FeedQuery) ([]*model.Activity, string, error)
+}
+
+/* Only users with unlisted or public profiles can be followed */
+func (s *feedService) Follow(followerID int64, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	user, err := findVisibleUser(ctx, s.user, username)
+	if err != nil {
+		return err
+	}
+	if user.ID == followerID {
+		return fmt.Errorf("cannot follow yourself")
+	}
+	return s.follow.Follow(ctx, followerID, user.ID)
+}
+
+func (s *feedService) Unfollow(followerID int64, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	user, err := s.user.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.follow.Unfollow(ctx, followerID, user.ID)
+}
+
+func (s *feedService) GetFollowing(userID int64) ([]*model.FollowedUser, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.follow.GetFollowing(ctx, userID)
+}
+
+func (s *feedService) GetFollowers(userID int64) ([]*model.FollowedUser, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.follow.GetFollowers(ctx, userID)
+}
+
+/* Events of public lists of the followed users, newest first */
+func (s *feedService) GetFeed(query *model.FeedQuery) (*model.Feed, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	/* scores of the events are shown on the reader's scale */
+	scale, err := userScoreScale(ctx, s.user, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+	activities, nextCursor, err := s.activity.GetFeed(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, activity := range activities {
+		activity.Score = model.ScaleScore(activity.RawScore, scale)
+	}
+	return &model.Feed{Activities: activities, NextCursor: nextCursor}, nil
+}
+
+/*
+The feed is a side effect of the list operations, so a failure to record
+the event is only logged and doesn't fail the operation itself
+*/
+func recordActivity(ctx context.Context, repo ActivityRepository, kind string, movie *model.ListUnit) {
+	activity := &model.Activity{
+		UserID: movie.OwnerID,
+		ListID: movie.ListID,
+		Kind:   kind,
+		Movie:  model.Movie{ID: movie.ID},
+	}
+	if kind == model.ActivityScored {
+		activity.RawScore = movie.RawScore
+	}
+	if err := repo.Add(ctx, activity); err != nil {
+		log.Printf("feed: failed to record %q event of movie %d: %s", kind, movie.ID, err)
+	}
+}
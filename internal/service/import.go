@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/config"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+/* count of the search results that are shown for an ambiguous row */
+const importCandidates = 5
+
+type movieImporter struct {
+	list    *listService
+	imports ImportRepository
+	cfg     *config.ImportsConfig
+}
+
+type ImportRepository interface {
+	Create(context.Context, *model.Import) (*model.ImportProgress, error)
+	GetByID(context.Context, int64, int64) (*model.ImportProgress, error)
+	GetPending(context.Context, int) ([]*model.Import, error)
+	SaveRow(context.Context, int64, *model.ImportRow) error
+}
+
+/*
+Queue the imported rows, they're matched and added to the list in the background
+(see GetImport for the progress). The movies that are already in the list get the status
+and score of the row, unless it's a watchlist. Every row is processed on its own,
+so a failed row doesn't stop the import
+*/
+func (s *listService) ImportMovies(imp *model.Import) (*model.ImportProgress, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &imp.ListInfo); err != nil {
+		return nil, err
+	}
+	return s.imports.Create(ctx, imp)
+}
+
+/* Progress of the user's import with the report of the rows processed so far */
+func (s *listService) GetImport(ownerID, id int64) (*model.ImportProgress, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	progress, err := s.imports.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	scale, err := userScoreScale(ctx, s.user, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	progress.ImportReport = *model.NewImportReport()
+	for _, row := range progress.Rows {
+		row.Score = model.ScaleScore(row.RawScore, scale)
+		progress.Add(row)
+	}
+	return progress, nil
+}
+
+func (i *movieImporter) Run(ctx context.Context) {
+	runPeriodically(ctx, i.cfg.ProcessInterval, i.process)
+}
+
+/* Process a batch of the pending rows, the oldest imports first */
+func (i *movieImporter) process(ctx context.Context) error {
+	imports, err := i.imports.GetPending(ctx, i.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+	processed := 0
+	for _, imp := range imports {
+		for _, row := range imp.Rows {
+			/* the rows left are processed by the next run */
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if row.Error == "" {
+				i.list.importRow(&imp.ListInfo, row)
+			}
+			if err := i.imports.SaveRow(ctx, imp.ID, row); err != nil {
+				return err
+			}
+			processed++
+		}
+	}
+	if processed > 0 {
+		log.Printf("imports: %d rows have been processed", processed)
+	}
+	return nil
+}
+
+func (s *listService) importRow(list *model.ListInfo, row *model.ImportRow) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	full, err := s.matchImportRow(ctx, row)
+	if err != nil {
+		row.Error = err.Error()
+		return
+	}
+	if row.Movie == nil {
+		return
+	}
+	/* search results lack some info, so the movie is fetched in full */
+	if !full {
+		if err := s.fillFromKinopoisk(ctx, row.Movie); err != nil {
+			row.Error = err.Error()
+			return
+		}
+	}
+	var (
+		movie = &model.ListUnit{Movie: *row.Movie, Status: row.Status, RawScore: row.RawScore, ListInfo: *list}
+		patch = &model.ListUnitPatch{
+			ListID:  &list.ListID,
+			OwnerID: &list.OwnerID,
+			MovieID: &movie.ID,
+			Status:  &row.Status,
+		}
+		/* the previous state tells the actual changes to be shown in the feed */
+		prev = &model.ListUnit{Movie: model.Movie{ID: movie.ID}, ListInfo: *list}
+	)
+	if row.RawScore != 0 {
+		patch.RawScore = &row.RawScore
+	}
+	err = s.movie.Add(ctx, movie)
+	var conflictErr *model.ConflictError
+	switch {
+	case err == nil:
+		recordActivity(ctx, s.activity, model.ActivityAdded, movie)
+		/* the imported movie is usually completed and scored as well */
+		s.recordUpdate(ctx, prev, patch)
+	case errors.As(err, &conflictErr) && row.Status != "plan to watch":
+		if err = s.movie.GetByID(ctx, prev); err == nil {
+			err = s.movie.Update(ctx, patch)
+		}
+		if err == nil {
+			s.recordUpdate(ctx, prev, patch)
+		}
+	}
+	if err != nil && !errors.As(err, &conflictErr) {
+		row.Error = err.Error()
+	}
+}
+
+/*
+Rows with IMDb ID are matched exactly; if the ID isn't found, the row is searched by title
+and year like the rows without ID. If there are several results, the one with the very same
+title is chosen, otherwise the row is ambiguous. Only the movie matched by ID is fetched in full
+*/
+func (s *listService) matchImportRow(ctx context.Context, row *model.ImportRow) (bool, error) {
+	if row.IMDbID != "" {
+		movie, err := s.cache.FindByIMDbID(ctx, row.IMDbID)
+		var notFoundErr *model.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			if movie, err = s.searcher.SearchByIMDbID(ctx, row.IMDbID); err == nil {
+				err = s.cache.Save(ctx, movie)
+			}
+		}
+		if err == nil {
+			row.Movie = movie
+			return true, nil
+		}
+		if row.Title == "" {
+			return false, err
+		}
+	}
+	result, err := s.searcher.Search(ctx, &model.SearchQuery{Query: row.Title, Page: 1, Limit: 10, Year: row.Year})
+	if err != nil {
+		return false, err
+	}
+	var sameTitle []model.Movie
+	for _, doc := range result.Docs {
+		if strings.EqualFold(doc.Name, row.Title) || strings.EqualFold(doc.EnName, row.Title) ||
+			strings.EqualFold(doc.AlternativeName, row.Title) {
+			sameTitle = append(sameTitle, doc)
+		}
+	}
+	switch {
+	case len(result.Docs) == 0:
+		return false, fmt.Errorf("movie %q not found", row.Title)
+	case len(result.Docs) == 1:
+		row.Movie = &result.Docs[0]
+	case len(sameTitle) == 1:
+		row.Movie = &sameTitle[0]
+	default:
+		row.Candidates = result.Docs
+		if len(row.Candidates) > importCandidates {
+			row.Candidates = row.Candidates[:importCandidates]
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type BackgroundJob interface {
+	Run(context.Context)
+}
+
+/* Run the job every interval until ctx is done; a non-positive interval disables the job */
+func runPeriodically(ctx context.Context, interval time.Duration, job func(context.Context) error) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			jobCtx, cancel := context.WithTimeout(ctx, interval)
+			if err := job(jobCtx); err != nil {
+				log.Println(err.Error())
+			}
+			cancel()
+		}
+	}
+}
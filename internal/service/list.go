@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"sync"
+	"fmt"
 	"time"
 
 	"github.com/kiryu-dev/mykinolist/internal/model"
@@ -12,93 +12,510 @@ type listService struct {
 	searcher MovieSearcher
 	movie    MovieRepositroy
 	list     ListRepository
+	cache    MovieCacheRepository
+	activity ActivityRepository
+	status   StatusRepository
+	user     UserRepository
+	imports  ImportRepository
 }
 
 type MovieSearcher interface {
-	Search(context.Context, string) (*model.SearchResult, error)
+	Search(context.Context, *model.SearchQuery) (*model.SearchResult, error)
 	SearchByID(context.Context, int64) (*model.Movie, error)
+	SearchByIMDbID(context.Context, string) (*model.Movie, error)
 }
 
 type MovieRepositroy interface {
 	Add(context.Context, *model.ListUnit) error
-	GetAll(context.Context, int64) ([]*model.ListUnit, error)
+	GetAll(context.Context, *model.ListFilter) ([]*model.ListUnit, string, error)
 	GetByID(context.Context, *model.ListUnit) error
 	Update(context.Context, *model.ListUnitPatch) error
+	UpdateProgress(context.Context, *model.ListUnit, *model.Progress) error
+	GetHistory(context.Context, *model.ListUnit) ([]*model.HistoryEntry, error)
 	Delete(context.Context, *model.ListUnit) error
+	GetTrash(context.Context, *model.ListInfo) ([]*model.ListUnit, error)
+	Restore(context.Context, *model.ListUnit) error
+	Purge(context.Context, time.Time) (int64, error)
+	GetStats(context.Context, int64) (*model.Stats, error)
+	Batch(context.Context, *model.Batch) error
+	Rerank(context.Context, int64, func(RankReader) (map[int64]string, error)) error
+	GetSequelEntries(context.Context, *model.ListInfo) ([]*model.ListUnit, error)
+	GetSequels(context.Context, *model.ListInfo) ([]*model.Sequel, error)
 }
 
-/* Add the first found movie by the specified title to the [kino]list */
+const exportPageSize = 500
+
+/*
+Add the movie with the specified Kinopoisk ID to the [kino]list.
+If there's no ID, the first found movie by the specified title is added
+*/
 func (s *listService) AddMovie(movie *model.ListUnit) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := movie.Validate(); err != nil {
 		return err
 	}
-	searchResult, err := s.searcher.Search(ctx, movie.Name)
+	if err := resolveList(ctx, s.list, &movie.ListInfo); err != nil {
+		return err
+	}
+	if err := normalizeStatus(ctx, s.status, movie.OwnerID, &movie.Status); err != nil {
+		return err
+	}
+	scale, err := userScoreScale(ctx, s.user, movie.OwnerID)
 	if err != nil {
 		return err
 	}
-	movie.Movie = searchResult.Docs[0]
-	return s.movie.Add(ctx, movie)
+	if movie.RawScore, err = model.RawScore(movie.Score, scale); err != nil {
+		return err
+	}
+	if movie.ID == 0 {
+		searchResult, err := s.searcher.Search(ctx, &model.SearchQuery{Query: movie.Name, Page: 1, Limit: 1})
+		if err != nil {
+			return err
+		}
+		if len(searchResult.Docs) == 0 {
+			return &model.NotFoundError{Message: fmt.Sprintf("movie %q not found", movie.Name)}
+		}
+		movie.ID = searchResult.Docs[0].ID
+	}
+	if err := s.fillFromKinopoisk(ctx, &movie.Movie); err != nil {
+		return err
+	}
+	if err := s.movie.Add(ctx, movie); err != nil {
+		return err
+	}
+	recordActivity(ctx, s.activity, model.ActivityAdded, movie)
+	return nil
 }
 
-func (s *listService) GetMovies(userID int64) ([]*model.ListUnit, error) {
+func (s *listService) GetMovies(filter *model.ListFilter) (*model.ListPage, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	movies, err := s.movie.GetAll(ctx, userID)
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := resolveList(ctx, s.list, &filter.ListInfo); err != nil {
+		return nil, err
+	}
+	if filter.Status != "" {
+		if err := normalizeStatus(ctx, s.status, filter.OwnerID, &filter.Status); err != nil {
+			return nil, err
+		}
+	}
+	scale, err := userScoreScale(ctx, s.user, filter.OwnerID)
 	if err != nil {
 		return nil, err
 	}
-	for i, movieInfo := range movies {
-		movie, err := s.searcher.SearchByID(ctx, movieInfo.ID)
+	if err := scaleFilter(scale, filter); err != nil {
+		return nil, err
+	}
+	movies, nextCursor, err := s.movie.GetAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillMissing(ctx, movies); err != nil {
+		return nil, err
+	}
+	scaleMovies(scale, movies...)
+	return &model.ListPage{Movies: movies, NextCursor: nextCursor}, nil
+}
+
+/*
+Pass all the movies of the list to write page by page, oldest first, so that the whole
+list is never kept in memory. The filter narrows down the movies, its sort and paging are ignored
+*/
+func (s *listService) ExportMovies(filter *model.ListFilter, write func([]*model.ListUnit) error) error {
+	filter.Sort, filter.Order, filter.Cursor, filter.Limit = "added", "asc", "", exportPageSize
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	err := resolveList(ctx, s.list, &filter.ListInfo)
+	var scale string
+	if err == nil {
+		scale, err = userScoreScale(ctx, s.user, filter.OwnerID)
+	}
+	cancel()
+	if err != nil {
+		return err
+	}
+	if err := scaleFilter(scale, filter); err != nil {
+		return err
+	}
+	for {
+		movies, nextCursor, err := s.exportPage(filter)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		scaleMovies(scale, movies...)
+		if err := write(movies); err != nil {
+			return err
 		}
-		movies[i].Name = movie.Name
+		if nextCursor == "" {
+			return nil
+		}
+		filter.Cursor = nextCursor
 	}
-	return movies, nil
 }
 
+func (s *listService) exportPage(filter *model.ListFilter) ([]*model.ListUnit, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	movies, nextCursor, err := s.movie.GetAll(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	return movies, nextCursor, s.fillMissing(ctx, movies)
+}
+
+/* movies missing from the cache */
+func (s *listService) fillMissing(ctx context.Context, movies []*model.ListUnit) error {
+	for _, movie := range movies {
+		if movie.Name != "" {
+			continue
+		}
+		if err := s.fillEntry(ctx, movie); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *listService) GetMovie(movie *model.ListUnit) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &movie.ListInfo); err != nil {
+		return err
+	}
+	if err := s.movie.GetByID(ctx, movie); err != nil {
+		return err
+	}
+	if err := s.scaleScores(ctx, movie); err != nil {
+		return err
+	}
+	if movie.Name != "" {
+		return nil
+	}
+	return s.fillEntry(ctx, movie)
+}
+
+/* The version of the patch is checked if it's specified, then it's set to the new version */
 func (s *listService) UpdateMovie(movie *model.ListUnitPatch) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := movie.Validate(); err != nil {
 		return err
 	}
-	listID, err := s.list.GetID(ctx, *movie.OwnerID)
-	if err != nil {
+	list := &model.ListInfo{OwnerID: *movie.OwnerID}
+	if movie.ListID != nil {
+		list.ListID = *movie.ListID
+	}
+	if err := resolveList(ctx, s.list, list); err != nil {
+		return err
+	}
+	if movie.Status != nil {
+		if err := normalizeStatus(ctx, s.status, list.OwnerID, movie.Status); err != nil {
+			return err
+		}
+	}
+	if movie.Score != nil {
+		scale, err := userScoreScale(ctx, s.user, list.OwnerID)
+		if err != nil {
+			return err
+		}
+		raw, err := model.RawScore(*movie.Score, scale)
+		if err != nil {
+			return err
+		}
+		movie.RawScore = &raw
+	}
+	movie.ListID = &list.ListID
+	/* the previous state tells the actual changes to be shown in the feed */
+	prev := &model.ListUnit{Movie: model.Movie{ID: *movie.MovieID}, ListInfo: *list}
+	if err := s.movie.GetByID(ctx, prev); err != nil {
+		return err
+	}
+	if err := s.movie.Update(ctx, movie); err != nil {
 		return err
 	}
-	movie.ListID = &listID
-	return s.movie.Update(ctx, movie)
+	s.recordUpdate(ctx, prev, movie)
+	return nil
 }
 
-func (s *listService) DeleteMovie(movie *model.ListUnit) error {
+func (s *listService) recordUpdate(ctx context.Context, prev *model.ListUnit, patch *model.ListUnitPatch) {
+	if patch.Status != nil && *patch.Status == "completed" && prev.Status != "completed" {
+		recordActivity(ctx, s.activity, model.ActivityCompleted, prev)
+	}
+	if patch.RawScore != nil && *patch.RawScore != 0 && *patch.RawScore != prev.RawScore {
+		prev.RawScore = *patch.RawScore
+		recordActivity(ctx, s.activity, model.ActivityScored, prev)
+	}
+	if patch.IsFavorite != nil && *patch.IsFavorite && !prev.IsFavorite {
+		recordActivity(ctx, s.activity, model.ActivityFavorited, prev)
+	}
+}
+
+/*
+Move the progress of the series the specified count of episodes forward.
+The series becomes "watching", or "completed" once its last episode is watched
+*/
+func (s *listService) IncrementProgress(movie *model.ListUnit, inc *model.ProgressIncrement) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	if err := inc.Validate(); err != nil {
+		return err
+	}
+	if err := resolveList(ctx, s.list, &movie.ListInfo); err != nil {
+		return err
+	}
+	if err := s.movie.GetByID(ctx, movie); err != nil {
+		return err
+	}
+	if movie.Name == "" || movie.IsSeries && len(movie.EpisodesPerSeason) == 0 {
+		/* the cached movie may be missing or lack the seasons data */
+		if err := s.fillEntry(ctx, movie); err != nil {
+			return err
+		}
+	}
+	if !movie.IsSeries {
+		return fmt.Errorf("movie %d isn't a series", movie.ID)
+	}
+	if len(movie.EpisodesPerSeason) == 0 {
+		return fmt.Errorf("there's no info about seasons of the series %d", movie.ID)
+	}
 	var (
-		errChan = make(chan error, 2)
-		wg      = &sync.WaitGroup{}
+		prev       = *movie.Progress
+		prevStatus = movie.Status
 	)
-	wg.Add(2)
-	go func() {
-		errChan <- s.movie.GetByID(ctx, movie)
-		wg.Done()
-	}()
-	go func() {
-		movieInfo, err := s.searcher.SearchByID(ctx, movie.ID)
-		if err == nil {
-			movie.Name = movieInfo.Name
-		}
-		errChan <- err
-		wg.Done()
-	}()
-	wg.Wait()
-	close(errChan)
-	for err := range errChan {
-		if err != nil {
+	movie.Progress = model.NewProgress(prev.Season, prev.Episode, movie.EpisodesPerSeason)
+	movie.Progress.Advance(inc.Episodes, movie.EpisodesPerSeason)
+	movie.Status = "watching"
+	if movie.Progress.IsFinished() {
+		movie.Status = "completed"
+	}
+	if err := s.movie.UpdateProgress(ctx, movie, &prev); err != nil {
+		return err
+	}
+	if movie.Status == "completed" && prevStatus != "completed" {
+		recordActivity(ctx, s.activity, model.ActivityCompleted, movie)
+	}
+	return s.scaleScores(ctx, movie)
+}
+
+func (s *listService) GetHistory(movie *model.ListUnit) ([]*model.HistoryEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &movie.ListInfo); err != nil {
+		return nil, err
+	}
+	if err := s.movie.GetByID(ctx, movie); err != nil {
+		return nil, err
+	}
+	scale, err := userScoreScale(ctx, s.user, movie.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.movie.GetHistory(ctx, movie)
+	if err != nil {
+		return nil, err
+	}
+	scaleHistory(scale, history)
+	return history, nil
+}
+
+func (s *listService) DeleteMovie(movie *model.ListUnit) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &movie.ListInfo); err != nil {
+		return err
+	}
+	if err := s.movie.GetByID(ctx, movie); err != nil {
+		return err
+	}
+	if movie.Name == "" {
+		if err := s.fillEntry(ctx, movie); err != nil {
 			return err
 		}
 	}
+	if err := s.scaleScores(ctx, movie); err != nil {
+		return err
+	}
 	return s.movie.Delete(ctx, movie)
 }
+
+func (s *listService) GetTrash(list *model.ListInfo) ([]*model.ListUnit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, list); err != nil {
+		return nil, err
+	}
+	movies, err := s.movie.GetTrash(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.scaleScores(ctx, movies...); err != nil {
+		return nil, err
+	}
+	for _, movie := range movies {
+		if movie.Name != "" {
+			continue
+		}
+		if err := s.fillEntry(ctx, movie); err != nil {
+			return nil, err
+		}
+	}
+	return movies, nil
+}
+
+/* Bring the movie back from the trash with its status, score, etc. */
+func (s *listService) RestoreMovie(movie *model.ListUnit) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &movie.ListInfo); err != nil {
+		return err
+	}
+	if err := s.movie.Restore(ctx, movie); err != nil {
+		return err
+	}
+	if err := s.movie.GetByID(ctx, movie); err != nil {
+		return err
+	}
+	if err := s.scaleScores(ctx, movie); err != nil {
+		return err
+	}
+	if movie.Name != "" {
+		return nil
+	}
+	return s.fillEntry(ctx, movie)
+}
+
+/* Statistics of the list, it's the default one unless the list ID is specified */
+func (s *listService) GetStats(list *model.ListInfo) (*model.Stats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, list); err != nil {
+		return nil, err
+	}
+	scale, err := userScoreScale(ctx, s.user, list.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.movie.GetStats(ctx, list.ListID)
+	if err != nil {
+		return nil, err
+	}
+	scaleStats(scale, stats)
+	return stats, nil
+}
+
+func (s *listService) SearchMovies(query *model.SearchQuery) (*model.SearchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	return s.searcher.Search(ctx, query)
+}
+
+func (s *listService) CreateList(list *model.List) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := list.Validate(); err != nil {
+		return err
+	}
+	list.IsDefault = false
+	return s.list.Create(ctx, list)
+}
+
+func (s *listService) GetLists(ownerID int64) ([]*model.List, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.list.GetAll(ctx, ownerID)
+}
+
+func (s *listService) GetList(listID, ownerID int64) (*model.List, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.list.GetByID(ctx, listID, ownerID)
+}
+
+func (s *listService) UpdateList(list *model.ListPatch) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := list.Validate(); err != nil {
+		return err
+	}
+	return s.list.Update(ctx, list)
+}
+
+func (s *listService) DeleteList(listID, ownerID int64) (*model.List, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	list, err := s.list.GetByID(ctx, listID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if list.IsDefault {
+		return nil, fmt.Errorf("default list cannot be deleted")
+	}
+	if err := s.list.Delete(ctx, listID, ownerID); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+/* Show the scores of the entries on the scale of their owner */
+func (s *listService) scaleScores(ctx context.Context, movies ...*model.ListUnit) error {
+	if len(movies) == 0 {
+		return nil
+	}
+	scale, err := userScoreScale(ctx, s.user, movies[0].OwnerID)
+	if err != nil {
+		return err
+	}
+	scaleMovies(scale, movies...)
+	return nil
+}
+
+/* If no list is specified, the movie operation refers to the owner's default list */
+func resolveList(ctx context.Context, repo ListRepository, list *model.ListInfo) error {
+	if list.ListID == 0 {
+		id, err := repo.GetDefaultID(ctx, list.OwnerID)
+		if err != nil {
+			return err
+		}
+		list.ListID = id
+		return nil
+	}
+	_, err := repo.GetByID(ctx, list.ListID, list.OwnerID)
+	return err
+}
+
+/* Movies missing from the cache are fetched from Kinopoisk and cached */
+/* Fill the entry whose movie isn't cached, its progress is rebuilt from the stored season and episode */
+func (s *listService) fillEntry(ctx context.Context, movie *model.ListUnit) error {
+	if err := s.fillFromKinopoisk(ctx, &movie.Movie); err != nil {
+		return err
+	}
+	var season, episode int
+	if movie.Progress != nil {
+		season, episode = movie.Progress.Season, movie.Progress.Episode
+	}
+	movie.Progress = nil
+	if movie.IsSeries {
+		movie.Progress = model.NewProgress(season, episode, movie.EpisodesPerSeason)
+	}
+	return nil
+}
+
+func (s *listService) fillFromKinopoisk(ctx context.Context, movie *model.Movie) error {
+	info, err := s.searcher.SearchByID(ctx, movie.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.cache.Save(ctx, info); err != nil {
+		return err
+	}
+	*movie = *info
+	return nil
+}
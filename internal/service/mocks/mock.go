@@ -190,6 +190,50 @@ func (mr *MockListServiceMockRecorder) AddMovie(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovie", reflect.TypeOf((*MockListService)(nil).AddMovie), arg0)
 }
 
+// BatchMovies mocks base method.
+func (m *MockListService) BatchMovies(arg0 *model.Batch) (*model.BatchReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchMovies", arg0)
+	ret0, _ := ret[0].(*model.BatchReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchMovies indicates an expected call of BatchMovies.
+func (mr *MockListServiceMockRecorder) BatchMovies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchMovies", reflect.TypeOf((*MockListService)(nil).BatchMovies), arg0)
+}
+
+// CreateList mocks base method.
+func (m *MockListService) CreateList(arg0 *model.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateList", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateList indicates an expected call of CreateList.
+func (mr *MockListServiceMockRecorder) CreateList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateList", reflect.TypeOf((*MockListService)(nil).CreateList), arg0)
+}
+
+// DeleteList mocks base method.
+func (m *MockListService) DeleteList(arg0, arg1 int64) (*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", arg0, arg1)
+	ret0, _ := ret[0].(*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockListServiceMockRecorder) DeleteList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockListService)(nil).DeleteList), arg0, arg1)
+}
+
 // DeleteMovie mocks base method.
 func (m *MockListService) DeleteMovie(arg0 *model.ListUnit) error {
 	m.ctrl.T.Helper()
@@ -204,11 +248,114 @@ func (mr *MockListServiceMockRecorder) DeleteMovie(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockListService)(nil).DeleteMovie), arg0)
 }
 
+// ExportMovies mocks base method.
+func (m *MockListService) ExportMovies(arg0 *model.ListFilter, arg1 func([]*model.ListUnit) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMovies", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportMovies indicates an expected call of ExportMovies.
+func (mr *MockListServiceMockRecorder) ExportMovies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMovies", reflect.TypeOf((*MockListService)(nil).ExportMovies), arg0, arg1)
+}
+
+// GetCollections mocks base method.
+func (m *MockListService) GetCollections(arg0 *model.ListInfo) ([]*model.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", arg0)
+	ret0, _ := ret[0].([]*model.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockListServiceMockRecorder) GetCollections(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockListService)(nil).GetCollections), arg0)
+}
+
+// GetHistory mocks base method.
+func (m *MockListService) GetHistory(arg0 *model.ListUnit) ([]*model.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", arg0)
+	ret0, _ := ret[0].([]*model.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockListServiceMockRecorder) GetHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockListService)(nil).GetHistory), arg0)
+}
+
+// GetImport mocks base method.
+func (m *MockListService) GetImport(arg0, arg1 int64) (*model.ImportProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", arg0, arg1)
+	ret0, _ := ret[0].(*model.ImportProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockListServiceMockRecorder) GetImport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockListService)(nil).GetImport), arg0, arg1)
+}
+
+// GetList mocks base method.
+func (m *MockListService) GetList(arg0, arg1 int64) (*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0, arg1)
+	ret0, _ := ret[0].(*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockListServiceMockRecorder) GetList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockListService)(nil).GetList), arg0, arg1)
+}
+
+// GetLists mocks base method.
+func (m *MockListService) GetLists(arg0 int64) ([]*model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLists", arg0)
+	ret0, _ := ret[0].([]*model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLists indicates an expected call of GetLists.
+func (mr *MockListServiceMockRecorder) GetLists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockListService)(nil).GetLists), arg0)
+}
+
+// GetMovie mocks base method.
+func (m *MockListService) GetMovie(arg0 *model.ListUnit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovie", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetMovie indicates an expected call of GetMovie.
+func (mr *MockListServiceMockRecorder) GetMovie(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovie", reflect.TypeOf((*MockListService)(nil).GetMovie), arg0)
+}
+
 // GetMovies mocks base method.
-func (m *MockListService) GetMovies(arg0 int64) ([]*model.ListUnit, error) {
+func (m *MockListService) GetMovies(arg0 *model.ListFilter) (*model.ListPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMovies", arg0)
-	ret0, _ := ret[0].([]*model.ListUnit)
+	ret0, _ := ret[0].(*model.ListPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -219,6 +366,151 @@ func (mr *MockListServiceMockRecorder) GetMovies(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovies", reflect.TypeOf((*MockListService)(nil).GetMovies), arg0)
 }
 
+// GetStats mocks base method.
+func (m *MockListService) GetStats(arg0 *model.ListInfo) (*model.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0)
+	ret0, _ := ret[0].(*model.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockListServiceMockRecorder) GetStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockListService)(nil).GetStats), arg0)
+}
+
+// GetTrash mocks base method.
+func (m *MockListService) GetTrash(arg0 *model.ListInfo) ([]*model.ListUnit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", arg0)
+	ret0, _ := ret[0].([]*model.ListUnit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockListServiceMockRecorder) GetTrash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockListService)(nil).GetTrash), arg0)
+}
+
+// ImportMovies mocks base method.
+func (m *MockListService) ImportMovies(arg0 *model.Import) (*model.ImportProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportMovies", arg0)
+	ret0, _ := ret[0].(*model.ImportProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportMovies indicates an expected call of ImportMovies.
+func (mr *MockListServiceMockRecorder) ImportMovies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportMovies", reflect.TypeOf((*MockListService)(nil).ImportMovies), arg0)
+}
+
+// IncrementProgress mocks base method.
+func (m *MockListService) IncrementProgress(arg0 *model.ListUnit, arg1 *model.ProgressIncrement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementProgress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementProgress indicates an expected call of IncrementProgress.
+func (mr *MockListServiceMockRecorder) IncrementProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementProgress", reflect.TypeOf((*MockListService)(nil).IncrementProgress), arg0, arg1)
+}
+
+// MoveMovie mocks base method.
+func (m *MockListService) MoveMovie(arg0 *model.RankMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveMovie", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveMovie indicates an expected call of MoveMovie.
+func (mr *MockListServiceMockRecorder) MoveMovie(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveMovie", reflect.TypeOf((*MockListService)(nil).MoveMovie), arg0)
+}
+
+// PlanCollection mocks base method.
+func (m *MockListService) PlanCollection(arg0 *model.CollectionPlan) ([]*model.ListUnit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanCollection", arg0)
+	ret0, _ := ret[0].([]*model.ListUnit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanCollection indicates an expected call of PlanCollection.
+func (mr *MockListServiceMockRecorder) PlanCollection(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanCollection", reflect.TypeOf((*MockListService)(nil).PlanCollection), arg0)
+}
+
+// ReorderMovies mocks base method.
+func (m *MockListService) ReorderMovies(arg0 *model.RankOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderMovies", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderMovies indicates an expected call of ReorderMovies.
+func (mr *MockListServiceMockRecorder) ReorderMovies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderMovies", reflect.TypeOf((*MockListService)(nil).ReorderMovies), arg0)
+}
+
+// RestoreMovie mocks base method.
+func (m *MockListService) RestoreMovie(arg0 *model.ListUnit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMovie", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMovie indicates an expected call of RestoreMovie.
+func (mr *MockListServiceMockRecorder) RestoreMovie(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMovie", reflect.TypeOf((*MockListService)(nil).RestoreMovie), arg0)
+}
+
+// SearchMovies mocks base method.
+func (m *MockListService) SearchMovies(arg0 *model.SearchQuery) (*model.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMovies", arg0)
+	ret0, _ := ret[0].(*model.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMovies indicates an expected call of SearchMovies.
+func (mr *MockListServiceMockRecorder) SearchMovies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMovies", reflect.TypeOf((*MockListService)(nil).SearchMovies), arg0)
+}
+
+// UpdateList mocks base method.
+func (m *MockListService) UpdateList(arg0 *model.ListPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateList", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockListServiceMockRecorder) UpdateList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockListService)(nil).UpdateList), arg0)
+}
+
 // UpdateMovie mocks base method.
 func (m *MockListService) UpdateMovie(arg0 *model.ListUnitPatch) error {
 	m.ctrl.T.Helper()
@@ -232,3 +524,650 @@ func (mr *MockListServiceMockRecorder) UpdateMovie(arg0 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockListService)(nil).UpdateMovie), arg0)
 }
+
+// MockViewingService is a mock of ViewingService interface.
+type MockViewingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewingServiceMockRecorder
+}
+
+// MockViewingServiceMockRecorder is the mock recorder for MockViewingService.
+type MockViewingServiceMockRecorder struct {
+	mock *MockViewingService
+}
+
+// NewMockViewingService creates a new mock instance.
+func NewMockViewingService(ctrl *gomock.Controller) *MockViewingService {
+	mock := &MockViewingService{ctrl: ctrl}
+	mock.recorder = &MockViewingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewingService) EXPECT() *MockViewingServiceMockRecorder {
+	return m.recorder
+}
+
+// AddViewing mocks base method.
+func (m *MockViewingService) AddViewing(arg0 *model.Viewing) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddViewing", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddViewing indicates an expected call of AddViewing.
+func (mr *MockViewingServiceMockRecorder) AddViewing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddViewing", reflect.TypeOf((*MockViewingService)(nil).AddViewing), arg0)
+}
+
+// DeleteViewing mocks base method.
+func (m *MockViewingService) DeleteViewing(arg0 *model.Viewing) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteViewing", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteViewing indicates an expected call of DeleteViewing.
+func (mr *MockViewingServiceMockRecorder) DeleteViewing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteViewing", reflect.TypeOf((*MockViewingService)(nil).DeleteViewing), arg0)
+}
+
+// GetViewings mocks base method.
+func (m *MockViewingService) GetViewings(arg0 *model.ListUnit) ([]*model.Viewing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewings", arg0)
+	ret0, _ := ret[0].([]*model.Viewing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViewings indicates an expected call of GetViewings.
+func (mr *MockViewingServiceMockRecorder) GetViewings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewings", reflect.TypeOf((*MockViewingService)(nil).GetViewings), arg0)
+}
+
+// MockReviewService is a mock of ReviewService interface.
+type MockReviewService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewServiceMockRecorder
+}
+
+// MockReviewServiceMockRecorder is the mock recorder for MockReviewService.
+type MockReviewServiceMockRecorder struct {
+	mock *MockReviewService
+}
+
+// NewMockReviewService creates a new mock instance.
+func NewMockReviewService(ctrl *gomock.Controller) *MockReviewService {
+	mock := &MockReviewService{ctrl: ctrl}
+	mock.recorder = &MockReviewServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewService) EXPECT() *MockReviewServiceMockRecorder {
+	return m.recorder
+}
+
+// AddReview mocks base method.
+func (m *MockReviewService) AddReview(arg0 *model.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReview", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReview indicates an expected call of AddReview.
+func (mr *MockReviewServiceMockRecorder) AddReview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReview", reflect.TypeOf((*MockReviewService)(nil).AddReview), arg0)
+}
+
+// DeleteReview mocks base method.
+func (m *MockReviewService) DeleteReview(arg0 *model.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockReviewServiceMockRecorder) DeleteReview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockReviewService)(nil).DeleteReview), arg0)
+}
+
+// GetPublicReviews mocks base method.
+func (m *MockReviewService) GetPublicReviews(arg0 int64) ([]*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicReviews", arg0)
+	ret0, _ := ret[0].([]*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicReviews indicates an expected call of GetPublicReviews.
+func (mr *MockReviewServiceMockRecorder) GetPublicReviews(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicReviews", reflect.TypeOf((*MockReviewService)(nil).GetPublicReviews), arg0)
+}
+
+// GetReview mocks base method.
+func (m *MockReviewService) GetReview(arg0 *model.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockReviewServiceMockRecorder) GetReview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockReviewService)(nil).GetReview), arg0)
+}
+
+// UpdateReview mocks base method.
+func (m *MockReviewService) UpdateReview(arg0 *model.ReviewPatch) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReview", arg0)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReview indicates an expected call of UpdateReview.
+func (mr *MockReviewServiceMockRecorder) UpdateReview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockReviewService)(nil).UpdateReview), arg0)
+}
+
+// MockTagService is a mock of TagService interface.
+type MockTagService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagServiceMockRecorder
+}
+
+// MockTagServiceMockRecorder is the mock recorder for MockTagService.
+type MockTagServiceMockRecorder struct {
+	mock *MockTagService
+}
+
+// NewMockTagService creates a new mock instance.
+func NewMockTagService(ctrl *gomock.Controller) *MockTagService {
+	mock := &MockTagService{ctrl: ctrl}
+	mock.recorder = &MockTagServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagService) EXPECT() *MockTagServiceMockRecorder {
+	return m.recorder
+}
+
+// AttachTag mocks base method.
+func (m *MockTagService) AttachTag(arg0 *model.ListUnit, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachTag indicates an expected call of AttachTag.
+func (mr *MockTagServiceMockRecorder) AttachTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachTag", reflect.TypeOf((*MockTagService)(nil).AttachTag), arg0, arg1)
+}
+
+// CreateTag mocks base method.
+func (m *MockTagService) CreateTag(arg0 *model.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockTagServiceMockRecorder) CreateTag(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockTagService)(nil).CreateTag), arg0)
+}
+
+// DeleteTag mocks base method.
+func (m *MockTagService) DeleteTag(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockTagServiceMockRecorder) DeleteTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTagService)(nil).DeleteTag), arg0, arg1)
+}
+
+// DetachTag mocks base method.
+func (m *MockTagService) DetachTag(arg0 *model.ListUnit, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachTag indicates an expected call of DetachTag.
+func (mr *MockTagServiceMockRecorder) DetachTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachTag", reflect.TypeOf((*MockTagService)(nil).DetachTag), arg0, arg1)
+}
+
+// GetTags mocks base method.
+func (m *MockTagService) GetTags(arg0 int64) ([]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", arg0)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTagServiceMockRecorder) GetTags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTagService)(nil).GetTags), arg0)
+}
+
+// MergeTags mocks base method.
+func (m *MockTagService) MergeTags(arg0 *model.TagMerge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockTagServiceMockRecorder) MergeTags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockTagService)(nil).MergeTags), arg0)
+}
+
+// RenameTag mocks base method.
+func (m *MockTagService) RenameTag(arg0 *model.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockTagServiceMockRecorder) RenameTag(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockTagService)(nil).RenameTag), arg0)
+}
+
+// MockProfileService is a mock of ProfileService interface.
+type MockProfileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileServiceMockRecorder
+}
+
+// MockProfileServiceMockRecorder is the mock recorder for MockProfileService.
+type MockProfileServiceMockRecorder struct {
+	mock *MockProfileService
+}
+
+// NewMockProfileService creates a new mock instance.
+func NewMockProfileService(ctrl *gomock.Controller) *MockProfileService {
+	mock := &MockProfileService{ctrl: ctrl}
+	mock.recorder = &MockProfileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileService) EXPECT() *MockProfileServiceMockRecorder {
+	return m.recorder
+}
+
+// GetProfile mocks base method.
+func (m *MockProfileService) GetProfile(arg0 string) (*model.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", arg0)
+	ret0, _ := ret[0].(*model.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockProfileServiceMockRecorder) GetProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockProfileService)(nil).GetProfile), arg0)
+}
+
+// GetPublicList mocks base method.
+func (m *MockProfileService) GetPublicList(arg0, arg1 string, arg2 *model.ListFilter) (*model.PublicList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.PublicList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicList indicates an expected call of GetPublicList.
+func (mr *MockProfileServiceMockRecorder) GetPublicList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicList", reflect.TypeOf((*MockProfileService)(nil).GetPublicList), arg0, arg1, arg2)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockProfileService) UpdateSettings(arg0 *model.UserSettings) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", arg0)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockProfileServiceMockRecorder) UpdateSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockProfileService)(nil).UpdateSettings), arg0)
+}
+
+// MockFeedService is a mock of FeedService interface.
+type MockFeedService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedServiceMockRecorder
+}
+
+// MockFeedServiceMockRecorder is the mock recorder for MockFeedService.
+type MockFeedServiceMockRecorder struct {
+	mock *MockFeedService
+}
+
+// NewMockFeedService creates a new mock instance.
+func NewMockFeedService(ctrl *gomock.Controller) *MockFeedService {
+	mock := &MockFeedService{ctrl: ctrl}
+	mock.recorder = &MockFeedServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedService) EXPECT() *MockFeedServiceMockRecorder {
+	return m.recorder
+}
+
+// Follow mocks base method.
+func (m *MockFeedService) Follow(arg0 int64, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockFeedServiceMockRecorder) Follow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*MockFeedService)(nil).Follow), arg0, arg1)
+}
+
+// GetFeed mocks base method.
+func (m *MockFeedService) GetFeed(arg0 *model.FeedQuery) (*model.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", arg0)
+	ret0, _ := ret[0].(*model.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockFeedServiceMockRecorder) GetFeed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockFeedService)(nil).GetFeed), arg0)
+}
+
+// GetFollowers mocks base method.
+func (m *MockFeedService) GetFollowers(arg0 int64) ([]*model.FollowedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowers", arg0)
+	ret0, _ := ret[0].([]*model.FollowedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockFeedServiceMockRecorder) GetFollowers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockFeedService)(nil).GetFollowers), arg0)
+}
+
+// GetFollowing mocks base method.
+func (m *MockFeedService) GetFollowing(arg0 int64) ([]*model.FollowedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", arg0)
+	ret0, _ := ret[0].([]*model.FollowedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockFeedServiceMockRecorder) GetFollowing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockFeedService)(nil).GetFollowing), arg0)
+}
+
+// Unfollow mocks base method.
+func (m *MockFeedService) Unfollow(arg0 int64, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *MockFeedServiceMockRecorder) Unfollow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*MockFeedService)(nil).Unfollow), arg0, arg1)
+}
+
+// MockRecommendationService is a mock of RecommendationService interface.
+type MockRecommendationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationServiceMockRecorder
+}
+
+// MockRecommendationServiceMockRecorder is the mock recorder for MockRecommendationService.
+type MockRecommendationServiceMockRecorder struct {
+	mock *MockRecommendationService
+}
+
+// NewMockRecommendationService creates a new mock instance.
+func NewMockRecommendationService(ctrl *gomock.Controller) *MockRecommendationService {
+	mock := &MockRecommendationService{ctrl: ctrl}
+	mock.recorder = &MockRecommendationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationService) EXPECT() *MockRecommendationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRecommendations mocks base method.
+func (m *MockRecommendationService) GetRecommendations(arg0 *model.RecommendationQuery) ([]*model.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecommendations", arg0)
+	ret0, _ := ret[0].([]*model.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecommendations indicates an expected call of GetRecommendations.
+func (mr *MockRecommendationServiceMockRecorder) GetRecommendations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendations", reflect.TypeOf((*MockRecommendationService)(nil).GetRecommendations), arg0)
+}
+
+// MockStatusService is a mock of StatusService interface.
+type MockStatusService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusServiceMockRecorder
+}
+
+// MockStatusServiceMockRecorder is the mock recorder for MockStatusService.
+type MockStatusServiceMockRecorder struct {
+	mock *MockStatusService
+}
+
+// NewMockStatusService creates a new mock instance.
+func NewMockStatusService(ctrl *gomock.Controller) *MockStatusService {
+	mock := &MockStatusService{ctrl: ctrl}
+	mock.recorder = &MockStatusServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusService) EXPECT() *MockStatusServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateStatus mocks base method.
+func (m *MockStatusService) CreateStatus(arg0 *model.Status) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateStatus indicates an expected call of CreateStatus.
+func (mr *MockStatusServiceMockRecorder) CreateStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatus", reflect.TypeOf((*MockStatusService)(nil).CreateStatus), arg0)
+}
+
+// GetStatuses mocks base method.
+func (m *MockStatusService) GetStatuses(arg0 int64) ([]*model.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatuses", arg0)
+	ret0, _ := ret[0].([]*model.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatuses indicates an expected call of GetStatuses.
+func (mr *MockStatusServiceMockRecorder) GetStatuses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatuses", reflect.TypeOf((*MockStatusService)(nil).GetStatuses), arg0)
+}
+
+// RemoveStatus mocks base method.
+func (m *MockStatusService) RemoveStatus(arg0 *model.StatusRemoval) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveStatus indicates an expected call of RemoveStatus.
+func (mr *MockStatusServiceMockRecorder) RemoveStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStatus", reflect.TypeOf((*MockStatusService)(nil).RemoveStatus), arg0)
+}
+
+// RenameStatus mocks base method.
+func (m *MockStatusService) RenameStatus(arg0 *model.Status) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameStatus indicates an expected call of RenameStatus.
+func (mr *MockStatusServiceMockRecorder) RenameStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameStatus", reflect.TypeOf((*MockStatusService)(nil).RenameStatus), arg0)
+}
+
+// ReorderStatuses mocks base method.
+func (m *MockStatusService) ReorderStatuses(arg0 *model.StatusOrder) ([]*model.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderStatuses", arg0)
+	ret0, _ := ret[0].([]*model.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderStatuses indicates an expected call of ReorderStatuses.
+func (mr *MockStatusServiceMockRecorder) ReorderStatuses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderStatuses", reflect.TypeOf((*MockStatusService)(nil).ReorderStatuses), arg0)
+}
+
+// MockNotificationService is a mock of NotificationService interface.
+type MockNotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceMockRecorder
+}
+
+// MockNotificationServiceMockRecorder is the mock recorder for MockNotificationService.
+type MockNotificationServiceMockRecorder struct {
+	mock *MockNotificationService
+}
+
+// NewMockNotificationService creates a new mock instance.
+func NewMockNotificationService(ctrl *gomock.Controller) *MockNotificationService {
+	mock := &MockNotificationService{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// DismissNotification mocks base method.
+func (m *MockNotificationService) DismissNotification(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissNotification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissNotification indicates an expected call of DismissNotification.
+func (mr *MockNotificationServiceMockRecorder) DismissNotification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissNotification", reflect.TypeOf((*MockNotificationService)(nil).DismissNotification), arg0, arg1)
+}
+
+// DismissNotifications mocks base method.
+func (m *MockNotificationService) DismissNotifications(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissNotifications", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DismissNotifications indicates an expected call of DismissNotifications.
+func (mr *MockNotificationServiceMockRecorder) DismissNotifications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissNotifications", reflect.TypeOf((*MockNotificationService)(nil).DismissNotifications), arg0)
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotificationService) GetNotifications(arg0 int64) ([]*model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", arg0)
+	ret0, _ := ret[0].([]*model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationServiceMockRecorder) GetNotifications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotificationService)(nil).GetNotifications), arg0)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/config"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type notificationService struct {
+	notification NotificationRepository
+}
+
+type releaseNotifier struct {
+	searcher     MovieSearcher
+	cache        MovieCacheRepository
+	notification NotificationRepository
+	user         UserRepository
+	sender       EmailSender
+	cfg          *config.NotificationsConfig
+}
+
+type NotificationRepository interface {
+	CreateReleased(context.Context, time.Time) ([]*model.Notification, error)
+	GetAll(context.Context, int64) ([]*model.Notification, error)
+	Dismiss(context.Context, int64, int64) error
+	DismissAll(context.Context, int64) (int64, error)
+}
+
+/* Sends the emails, e.g. over SMTP. Without a sender the notifications are only shown in the app */
+type EmailSender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+func (s *notificationService) GetNotifications(userID int64) ([]*model.Notification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.notification.GetAll(ctx, userID)
+}
+
+func (s *notificationService) DismissNotification(userID, id int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.notification.Dismiss(ctx, userID, id)
+}
+
+func (s *notificationService) DismissNotifications(userID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.notification.DismissAll(ctx, userID)
+}
+
+func (n *releaseNotifier) Run(ctx context.Context) {
+	runPeriodically(ctx, n.cfg.CheckInterval, n.notify)
+}
+
+/*
+Release dates of the movies planned to watch are re-fetched more often than the rest
+of the cache, as they're often unknown or moved before the release. A movie that fails
+to refresh is skipped and marked like in the cache refresh, so that it doesn't hold up
+the others; the notifications are created from the dates known so far anyway
+*/
+func (n *releaseNotifier) notify(ctx context.Context) error {
+	ids, err := n.cache.GetUnreleased(ctx, time.Now().Add(-n.cfg.RefreshInterval), n.cfg.RefreshBatchSize)
+	if err != nil {
+		log.Printf("notifications: %s", err.Error())
+	}
+	for _, id := range ids {
+		if err := refreshMovie(ctx, n.searcher, n.cache, id); err != nil {
+			log.Printf("notifications: refresh movie %d: %s", id, err.Error())
+		}
+	}
+	notifications, err := n.notification.CreateReleased(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(notifications) > 0 {
+		log.Printf("notifications: %d releases have been notified", len(notifications))
+	}
+	if n.sender != nil {
+		n.sendEmails(ctx, notifications)
+	}
+	return nil
+}
+
+/* a failed email isn't sent again, the notification is in the app anyway */
+func (n *releaseNotifier) sendEmails(ctx context.Context, notifications []*model.Notification) {
+	var user *model.User
+	for _, notification := range notifications {
+		/* the notifications are ordered by user */
+		if user == nil || user.ID != notification.UserID {
+			var err error
+			if user, err = n.user.FindByID(ctx, notification.UserID); err != nil {
+				log.Println(err.Error())
+				user = nil
+				continue
+			}
+		}
+		if !user.EmailNotifications {
+			continue
+		}
+		subject, body := notification.Email()
+		if err := n.sender.Send(ctx, user.Email, subject, body); err != nil {
+			log.Printf("notifications: email to user %d: %s", user.ID, err.Error())
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type profileService struct {
+	user   UserRepository
+	list   ListRepository
+	movies ListService
+}
+
+func (s *profileService) GetProfile(username string) (*model.Profile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	user, err := findVisibleUser(ctx, s.user, username)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := s.list.GetAll(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	profile := &model.Profile{
+		Username:  user.Username,
+		CreatedOn: user.CreatedOn,
+		Lists:     make([]*model.List, 0),
+	}
+	/* unlisted lists are only available by the link, so they aren't shown on the profile */
+	for _, list := range lists {
+		if list.Visibility == model.VisibilityPublic {
+			profile.Lists = append(profile.Lists, list)
+		}
+	}
+	return profile, nil
+}
+
+/*
+A list is available as long as it isn't private, even if the profile itself is private:
+the owner has shared the link to the list on purpose
+*/
+func (s *profileService) GetPublicList(username, slug string, filter *model.ListFilter) (*model.PublicList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	listID, err := model.ListIDFromSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	notFound := &model.NotFoundError{Message: fmt.Sprintf("list %q doesn't exist", slug)}
+	user, err := s.user.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.list.GetByID(ctx, listID, user.ID)
+	if _, ok := err.(*model.NotFoundError); ok {
+		return nil, notFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if list.Visibility == model.VisibilityPrivate {
+		return nil, notFound
+	}
+	filter.ListInfo = model.ListInfo{ListID: list.ID, OwnerID: user.ID}
+	page, err := s.movies.GetMovies(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PublicList{List: list, ListPage: *page}, nil
+}
+
+func (s *profileService) UpdateSettings(settings *model.UserSettings) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.user.UpdateSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return s.user.FindByID(ctx, settings.ID)
+}
+
+/* private profiles are reported as missing, so that they can't be told apart from nonexistent ones */
+func findVisibleUser(ctx context.Context, repo UserRepository, username string) (*model.User, error) {
+	user, err := repo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user.Visibility == model.VisibilityPrivate {
+		return nil, &model.NotFoundError{Message: fmt.Sprintf("user %q doesn't exist", username)}
+	}
+	return user, nil
+}
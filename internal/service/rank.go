@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+/* Reads the ranks of the list within the transaction of MovieRepositroy.Rerank */
+type RankReader interface {
+	GetRanks(context.Context, []int64) (map[int64]string, error)
+	GetNeighborRank(context.Context, string, bool) (string, error)
+}
+
+/*
+Put the movies of the list in the given order. The movies whose ranks are already
+in order keep them, the others get ranks between their new neighbours; the movies before
+the first kept one and after the last kept one are put right next to it
+*/
+func (s *listService) ReorderMovies(order *model.RankOrder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := order.Validate(); err != nil {
+		return err
+	}
+	if err := resolveList(ctx, s.list, &order.ListInfo); err != nil {
+		return err
+	}
+	return s.movie.Rerank(ctx, order.ListID, func(reader RankReader) (map[int64]string, error) {
+		return reorderRanks(ctx, reader, order)
+	})
+}
+
+func reorderRanks(ctx context.Context, reader RankReader, order *model.RankOrder) (map[int64]string, error) {
+	ranks, err := reader.GetRanks(ctx, order.IDs)
+	if err != nil {
+		return nil, err
+	}
+	current := make([]string, len(order.IDs))
+	for i, id := range order.IDs {
+		current[i] = ranks[id]
+	}
+	var (
+		misplaced   = model.MisplacedRanks(current)
+		first, last = -1, -1
+	)
+	for i := range misplaced {
+		if !misplaced[i] {
+			if first == -1 {
+				first = i
+			}
+			last = i
+		}
+	}
+	var lower, upper string
+	if first > 0 {
+		if lower, err = reader.GetNeighborRank(ctx, current[first], false); err != nil {
+			return nil, err
+		}
+	}
+	if last < len(current)-1 {
+		if upper, err = reader.GetNeighborRank(ctx, current[last], true); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		changed = make(map[int64]string)
+		prev    = lower
+	)
+	for i := 0; i < len(current); {
+		if !misplaced[i] {
+			prev = current[i]
+			i++
+			continue
+		}
+		j := i
+		for j < len(current) && misplaced[j] {
+			j++
+		}
+		next := upper
+		if j < len(current) {
+			next = current[j]
+		}
+		for k, rank := range model.RanksBetween(prev, next, j-i) {
+			changed[order.IDs[i+k]] = rank
+		}
+		i = j
+	}
+	return changed, nil
+}
+
+/* Move the movie right before or right after another one, the other movies keep their ranks */
+func (s *listService) MoveMovie(move *model.RankMove) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := move.Validate(); err != nil {
+		return err
+	}
+	if err := resolveList(ctx, s.list, &move.ListInfo); err != nil {
+		return err
+	}
+	return s.movie.Rerank(ctx, move.ListID, func(reader RankReader) (map[int64]string, error) {
+		ranks, err := reader.GetRanks(ctx, []int64{move.MovieID, move.Target()})
+		if err != nil {
+			return nil, err
+		}
+		var (
+			target = ranks[move.Target()]
+			after  = move.After != nil
+		)
+		neighbor, err := reader.GetNeighborRank(ctx, target, after)
+		if err != nil {
+			return nil, err
+		}
+		rank := model.RankBetween(neighbor, target)
+		if after {
+			rank = model.RankBetween(target, neighbor)
+		}
+		return map[int64]string{move.MovieID: rank}, nil
+	})
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/config"
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type recommendationService struct {
+	recommendation RecommendationRepository
+}
+
+type RecommendationRepository interface {
+	ComputeSimilarities(context.Context, int, int) (int64, error)
+	GetSimilar(context.Context, int64, int) ([]*model.Recommendation, error)
+	GetByGenres(context.Context, int64, []int64, int) ([]*model.Recommendation, error)
+}
+
+/*
+Titles similar to the ones the user has scored high or favorited. If there are too few
+of them (e.g. the account is new), the rest are suggested by the user's favorite genres
+*/
+func (s *recommendationService) GetRecommendations(query *model.RecommendationQuery) ([]*model.Recommendation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	recommendations, err := s.recommendation.GetSimilar(ctx, query.UserID, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(recommendations) == query.Limit {
+		return recommendations, nil
+	}
+	exclude := make([]int64, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		exclude = append(exclude, recommendation.ID)
+	}
+	byGenres, err := s.recommendation.GetByGenres(ctx, query.UserID, exclude, query.Limit-len(recommendations))
+	if err != nil {
+		return nil, err
+	}
+	return append(recommendations, byGenres...), nil
+}
+
+type similarityRefresher struct {
+	recommendation RecommendationRepository
+	cfg            *config.RecommendationsConfig
+}
+
+func (r *similarityRefresher) Run(ctx context.Context) {
+	runPeriodically(ctx, r.cfg.RefreshInterval, r.refresh)
+}
+
+/* Similarities are computed in the background, so that the recommendations are fast to get */
+func (r *similarityRefresher) refresh(ctx context.Context) error {
+	count, err := r.recommendation.ComputeSimilarities(ctx, r.cfg.MinCommonUsers, r.cfg.Neighbors)
+	if err != nil {
+		return err
+	}
+	log.Printf("recommendations: %d similar pairs of movies have been computed", count)
+	return nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type reviewService struct {
+	review   ReviewRepository
+	list     ListRepository
+	renderer MarkdownRenderer
+	activity ActivityRepository
+}
+
+type ReviewRepository interface {
+	Add(context.Context, *model.Review) error
+	Get(context.Context, *model.Review) error
+	GetPublic(context.Context, int64) ([]*model.Review, error)
+	Update(context.Context, *model.ReviewPatch) error
+	Delete(context.Context, *model.Review) error
+}
+
+type MarkdownRenderer interface {
+	Render(string) (string, error)
+}
+
+func (s *reviewService) AddReview(review *model.Review) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := review.Validate(); err != nil {
+		return err
+	}
+	if err := resolveList(ctx, s.list, &review.ListInfo); err != nil {
+		return err
+	}
+	if err := s.review.Add(ctx, review); err != nil {
+		return err
+	}
+	/* the event is shown in the feed only while the review is public */
+	recordActivity(ctx, s.activity, model.ActivityReviewed,
+		&model.ListUnit{Movie: model.Movie{ID: review.MovieID}, ListInfo: review.ListInfo})
+	return s.render(review)
+}
+
+func (s *reviewService) GetReview(review *model.Review) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &review.ListInfo); err != nil {
+		return err
+	}
+	if err := s.review.Get(ctx, review); err != nil {
+		return err
+	}
+	return s.render(review)
+}
+
+/* Reviews of the movie that their authors have made public */
+func (s *reviewService) GetPublicReviews(movieID int64) ([]*model.Review, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	reviews, err := s.review.GetPublic(ctx, movieID)
+	if err != nil {
+		return nil, err
+	}
+	for _, review := range reviews {
+		if err := s.render(review); err != nil {
+			return nil, err
+		}
+	}
+	return reviews, nil
+}
+
+func (s *reviewService) UpdateReview(patch *model.ReviewPatch) (*model.Review, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := patch.Validate(); err != nil {
+		return nil, err
+	}
+	if err := resolveList(ctx, s.list, &patch.ListInfo); err != nil {
+		return nil, err
+	}
+	if err := s.review.Update(ctx, patch); err != nil {
+		return nil, err
+	}
+	review := &model.Review{MovieID: patch.MovieID, ListInfo: patch.ListInfo}
+	if err := s.review.Get(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, s.render(review)
+}
+
+func (s *reviewService) DeleteReview(review *model.Review) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &review.ListInfo); err != nil {
+		return err
+	}
+	return s.review.Delete(ctx, review)
+}
+
+/* HTML is never stored, so the sanitizing policy applies to old reviews too */
+func (s *reviewService) render(review *model.Review) error {
+	html, err := s.renderer.Render(review.Body)
+	if err != nil {
+		return err
+	}
+	review.HTML = html
+	return nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+/* Scores are entered and shown on the scale the user has chosen, they're kept on the 0-100 scale */
+func userScoreScale(ctx context.Context, repo UserRepository, userID int64) (string, error) {
+	user, err := repo.FindByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return user.ScoreScale, nil
+}
+
+func scaleMovies(scale string, movies ...*model.ListUnit) {
+	for _, movie := range movies {
+		movie.Score = model.ScaleScore(movie.RawScore, scale)
+	}
+}
+
+func scaleHistory(scale string, history []*model.HistoryEntry) {
+	for _, entry := range history {
+		entry.Score = model.ScaleScore(entry.RawScore, scale)
+		if entry.OldRawScore != nil {
+			score := model.ScaleScore(*entry.OldRawScore, scale)
+			entry.OldScore = &score
+		}
+	}
+}
+
+/* the mean scores aren't rounded to the step of the scale */
+func scaleStats(scale string, stats *model.Stats) {
+	stats.MeanScore = model.ScaleMeanScore(stats.MeanScore, scale)
+	stats.ScoreDistribution = make(map[string]int, len(stats.RawScores))
+	for raw, count := range stats.RawScores {
+		stats.ScoreDistribution[model.FormatScore(model.ScaleScore(raw, scale))] += count
+	}
+	for _, buckets := range [][]*model.StatsBucket{stats.Genres, stats.Decades, stats.Countries} {
+		for _, bucket := range buckets {
+			bucket.MeanScore = model.ScaleMeanScore(bucket.MeanScore, scale)
+		}
+	}
+}
+
+/* the filter bounds include the raw scores that are shown as the bounds */
+func scaleFilter(scale string, filter *model.ListFilter) error {
+	for _, score := range []*float64{filter.MinScore, filter.MaxScore} {
+		if score == nil {
+			continue
+		}
+		if err := model.ValidateScoreBound(*score, scale); err != nil {
+			return err
+		}
+	}
+	if filter.MinScore != nil {
+		raw := model.MinRawScore(*filter.MinScore, scale)
+		filter.MinRawScore = &raw
+	}
+	if filter.MaxScore != nil {
+		raw := model.MaxRawScore(*filter.MaxScore, scale)
+		filter.MaxRawScore = &raw
+	}
+	return nil
+}
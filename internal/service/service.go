@@ -20,20 +20,138 @@ type AuthService interface {
 
 type ListService interface {
 	AddMovie(*model.ListUnit) error
-	GetMovies(int64) ([]*model.ListUnit, error)
+	ImportMovies(*model.Import) (*model.ImportProgress, error)
+	GetImport(int64, int64) (*model.ImportProgress, error)
+	BatchMovies(*model.Batch) (*model.BatchReport, error)
+	GetMovies(*model.ListFilter) (*model.ListPage, error)
+	ExportMovies(*model.ListFilter, func([]*model.ListUnit) error) error
+	GetMovie(*model.ListUnit) error
 	UpdateMovie(*model.ListUnitPatch) error
+	ReorderMovies(*model.RankOrder) error
+	MoveMovie(*model.RankMove) error
+	GetCollections(*model.ListInfo) ([]*model.Collection, error)
+	PlanCollection(*model.CollectionPlan) ([]*model.ListUnit, error)
+	IncrementProgress(*model.ListUnit, *model.ProgressIncrement) error
+	GetHistory(*model.ListUnit) ([]*model.HistoryEntry, error)
 	DeleteMovie(*model.ListUnit) error
+	GetTrash(*model.ListInfo) ([]*model.ListUnit, error)
+	RestoreMovie(*model.ListUnit) error
+	GetStats(*model.ListInfo) (*model.Stats, error)
+	SearchMovies(*model.SearchQuery) (*model.SearchResult, error)
+	CreateList(*model.List) error
+	GetLists(int64) ([]*model.List, error)
+	GetList(int64, int64) (*model.List, error)
+	UpdateList(*model.ListPatch) error
+	DeleteList(int64, int64) (*model.List, error)
+}
+
+type ViewingService interface {
+	AddViewing(*model.Viewing) error
+	GetViewings(*model.ListUnit) ([]*model.Viewing, error)
+	DeleteViewing(*model.Viewing) error
+}
+
+type ReviewService interface {
+	AddReview(*model.Review) error
+	GetReview(*model.Review) error
+	GetPublicReviews(int64) ([]*model.Review, error)
+	UpdateReview(*model.ReviewPatch) (*model.Review, error)
+	DeleteReview(*model.Review) error
+}
+
+type TagService interface {
+	CreateTag(*model.Tag) error
+	GetTags(int64) ([]*model.Tag, error)
+	RenameTag(*model.Tag) error
+	MergeTags(*model.TagMerge) error
+	DeleteTag(int64, int64) error
+	AttachTag(*model.ListUnit, int64) error
+	DetachTag(*model.ListUnit, int64) error
+}
+
+type ProfileService interface {
+	GetProfile(string) (*model.Profile, error)
+	GetPublicList(string, string, *model.ListFilter) (*model.PublicList, error)
+	UpdateSettings(*model.UserSettings) (*model.User, error)
+}
+
+type FeedService interface {
+	Follow(int64, string) error
+	Unfollow(int64, string) error
+	GetFollowing(int64) ([]*model.FollowedUser, error)
+	GetFollowers(int64) ([]*model.FollowedUser, error)
+	GetFeed(*model.FeedQuery) (*model.Feed, error)
+}
+
+type RecommendationService interface {
+	GetRecommendations(*model.RecommendationQuery) ([]*model.Recommendation, error)
+}
+
+type StatusService interface {
+	CreateStatus(*model.Status) error
+	GetStatuses(int64) ([]*model.Status, error)
+	RenameStatus(*model.Status) error
+	ReorderStatuses(*model.StatusOrder) ([]*model.Status, error)
+	RemoveStatus(*model.StatusRemoval) error
+}
+
+type NotificationService interface {
+	GetNotifications(int64) ([]*model.Notification, error)
+	DismissNotification(int64, int64) error
+	DismissNotifications(int64) (int64, error)
 }
 
 type Service struct {
 	AuthService
 	ListService
+	ViewingService
+	ReviewService
+	TagService
+	ProfileService
+	FeedService
+	RecommendationService
+	StatusService
+	NotificationService
+	Jobs []BackgroundJob
+}
+
+type Repositories struct {
+	User           UserRepository
+	Token          TokenRepository
+	List           ListRepository
+	Movie          MovieRepositroy
+	Cache          MovieCacheRepository
+	Viewing        ViewingRepository
+	Review         ReviewRepository
+	Tag            TagRepository
+	Follow         FollowRepository
+	Activity       ActivityRepository
+	Recommendation RecommendationRepository
+	Status         StatusRepository
+	Notification   NotificationRepository
+	Import         ImportRepository
 }
 
-func New(user UserRepository, token TokenRepository, list ListRepository,
-	movie MovieRepositroy, searcher MovieSearcher, config *config.Config) *Service {
+/* sender may be nil, then the notifications aren't emailed */
+func New(repo *Repositories, searcher MovieSearcher, renderer MarkdownRenderer, sender EmailSender, config *config.Config) *Service {
+	listService := &listService{searcher, repo.Movie, repo.List, repo.Cache, repo.Activity, repo.Status, repo.User, repo.Import}
 	return &Service{
-		AuthService: &authService{user, token, list, config},
-		ListService: &listService{searcher, movie, list},
+		AuthService:           &authService{repo.User, repo.Token, repo.List, repo.Status, config},
+		ListService:           listService,
+		ViewingService:        &viewingService{repo.Viewing, repo.List, repo.User, repo.Movie, repo.Activity},
+		ReviewService:         &reviewService{repo.Review, repo.List, renderer, repo.Activity},
+		TagService:            &tagService{repo.Tag, repo.List},
+		ProfileService:        &profileService{repo.User, repo.List, listService},
+		FeedService:           &feedService{repo.Follow, repo.Activity, repo.User},
+		RecommendationService: &recommendationService{repo.Recommendation},
+		StatusService:         &statusService{repo.Status},
+		NotificationService:   &notificationService{repo.Notification},
+		Jobs: []BackgroundJob{
+			&movieCacheRefresher{searcher, repo.Cache, config.MoviesCache},
+			&trashPurger{repo.Movie, config.Trash},
+			&similarityRefresher{repo.Recommendation, config.Recommendations},
+			&releaseNotifier{searcher, repo.Cache, repo.Notification, repo.User, sender, config.Notifications},
+			&movieImporter{listService, repo.Import, config.Imports},
+		},
 	}
 }
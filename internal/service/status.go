@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type statusService struct {
+	status StatusRepository
+}
+
+type StatusRepository interface {
+	CreateDefaults(context.Context, int64) error
+	Create(context.Context, *model.Status) error
+	GetAll(context.Context, int64) ([]*model.Status, error)
+	Rename(context.Context, *model.Status) error
+	Reorder(context.Context, *model.StatusOrder) error
+	Remove(context.Context, *model.StatusRemoval) error
+}
+
+func (s *statusService) CreateStatus(status *model.Status) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := status.Validate(); err != nil {
+		return err
+	}
+	return s.status.Create(ctx, status)
+}
+
+func (s *statusService) GetStatuses(ownerID int64) ([]*model.Status, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.status.GetAll(ctx, ownerID)
+}
+
+func (s *statusService) RenameStatus(status *model.Status) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := status.Validate(); err != nil {
+		return err
+	}
+	return s.status.Rename(ctx, status)
+}
+
+func (s *statusService) ReorderStatuses(order *model.StatusOrder) ([]*model.Status, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	statuses, err := s.status.GetAll(ctx, order.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(order.IDs) != len(statuses) {
+		return nil, fmt.Errorf("all %d statuses must be specified", len(statuses))
+	}
+	ids := make(map[int64]bool, len(statuses))
+	for _, status := range statuses {
+		ids[status.ID] = true
+	}
+	for _, id := range order.IDs {
+		if !ids[id] {
+			return nil, &model.NotFoundError{Message: fmt.Sprintf("status %d doesn't exist", id)}
+		}
+	}
+	if err := s.status.Reorder(ctx, order); err != nil {
+		return nil, err
+	}
+	return s.status.GetAll(ctx, order.OwnerID)
+}
+
+func (s *statusService) RemoveStatus(removal *model.StatusRemoval) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := removal.Validate(); err != nil {
+		return err
+	}
+	return s.status.Remove(ctx, removal)
+}
+
+/* Replace the status name with the user's status it matches */
+func normalizeStatus(ctx context.Context, repo StatusRepository, ownerID int64, status *string) error {
+	statuses, err := repo.GetAll(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	name, err := model.NormalizeStatus(*status, statuses)
+	if err != nil {
+		return err
+	}
+	*status = name
+	return nil
+}
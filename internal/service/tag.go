@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type tagService struct {
+	tag  TagRepository
+	list ListRepository
+}
+
+type TagRepository interface {
+	Create(context.Context, *model.Tag) error
+	GetByID(context.Context, int64, int64) (*model.Tag, error)
+	GetAll(context.Context, int64) ([]*model.Tag, error)
+	Rename(context.Context, *model.Tag) error
+	Merge(context.Context, *model.TagMerge) error
+	Delete(context.Context, int64, int64) error
+	Attach(context.Context, *model.ListUnit, int64) error
+	Detach(context.Context, *model.ListUnit, int64) error
+}
+
+func (s *tagService) CreateTag(tag *model.Tag) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+	return s.tag.Create(ctx, tag)
+}
+
+func (s *tagService) GetTags(ownerID int64) ([]*model.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.tag.GetAll(ctx, ownerID)
+}
+
+func (s *tagService) RenameTag(tag *model.Tag) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+	return s.tag.Rename(ctx, tag)
+}
+
+/* Move all the entries of the source tag to the target tag, the source tag is deleted */
+func (s *tagService) MergeTags(merge *model.TagMerge) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := merge.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.tag.GetByID(ctx, merge.TargetID, merge.OwnerID); err != nil {
+		return err
+	}
+	return s.tag.Merge(ctx, merge)
+}
+
+func (s *tagService) DeleteTag(tagID, ownerID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.tag.Delete(ctx, tagID, ownerID)
+}
+
+func (s *tagService) AttachTag(movie *model.ListUnit, tagID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &movie.ListInfo); err != nil {
+		return err
+	}
+	if _, err := s.tag.GetByID(ctx, tagID, movie.OwnerID); err != nil {
+		return err
+	}
+	return s.tag.Attach(ctx, movie, tagID)
+}
+
+func (s *tagService) DetachTag(movie *model.ListUnit, tagID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &movie.ListInfo); err != nil {
+		return err
+	}
+	return s.tag.Detach(ctx, movie, tagID)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/config"
+)
+
+type trashPurger struct {
+	movie MovieRepositroy
+	cfg   *config.TrashConfig
+}
+
+/* a non-positive retention keeps the trashed movies forever */
+func (p *trashPurger) Run(ctx context.Context) {
+	if p.cfg.Retention <= 0 {
+		return
+	}
+	runPeriodically(ctx, p.cfg.PurgeInterval, p.purge)
+}
+
+/* Permanently delete the movies that have been in the trash longer than the retention period */
+func (p *trashPurger) purge(ctx context.Context) error {
+	count, err := p.movie.Purge(ctx, time.Now().Add(-p.cfg.Retention))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		log.Printf("trash: %d movies have been purged", count)
+	}
+	return nil
+}
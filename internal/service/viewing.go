@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiryu-dev/mykinolist/internal/model"
+)
+
+type viewingService struct {
+	viewing  ViewingRepository
+	list     ListRepository
+	user     UserRepository
+	movie    MovieRepositroy
+	activity ActivityRepository
+}
+
+type ViewingRepository interface {
+	Add(context.Context, *model.Viewing) error
+	GetAll(context.Context, *model.ListUnit) ([]*model.Viewing, error)
+	Delete(context.Context, *model.Viewing) error
+}
+
+/* Record the viewing to the diary, the movie becomes completed */
+func (s *viewingService) AddViewing(viewing *model.Viewing) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := viewing.Validate(); err != nil {
+		return err
+	}
+	if err := resolveList(ctx, s.list, &viewing.ListInfo); err != nil {
+		return err
+	}
+	if viewing.Score != nil {
+		scale, err := userScoreScale(ctx, s.user, viewing.OwnerID)
+		if err != nil {
+			return err
+		}
+		raw, err := model.RawScore(*viewing.Score, scale)
+		if err != nil {
+			return err
+		}
+		viewing.RawScore = &raw
+	}
+	/* the previous status tells whether the movie has just been completed, to be shown in the feed */
+	prev := &model.ListUnit{Movie: model.Movie{ID: viewing.MovieID}, ListInfo: viewing.ListInfo}
+	if err := s.movie.GetByID(ctx, prev); err != nil {
+		return err
+	}
+	if err := s.viewing.Add(ctx, viewing); err != nil {
+		return err
+	}
+	if prev.Status != "completed" {
+		recordActivity(ctx, s.activity, model.ActivityCompleted, prev)
+	}
+	return nil
+}
+
+func (s *viewingService) GetViewings(movie *model.ListUnit) ([]*model.Viewing, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &movie.ListInfo); err != nil {
+		return nil, err
+	}
+	scale, err := userScoreScale(ctx, s.user, movie.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	viewings, err := s.viewing.GetAll(ctx, movie)
+	if err != nil {
+		return nil, err
+	}
+	for _, viewing := range viewings {
+		if viewing.RawScore != nil {
+			score := model.ScaleScore(*viewing.RawScore, scale)
+			viewing.Score = &score
+		}
+	}
+	return viewings, nil
+}
+
+func (s *viewingService) DeleteViewing(viewing *model.Viewing) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := resolveList(ctx, s.list, &viewing.ListInfo); err != nil {
+		return err
+	}
+	return s.viewing.Delete(ctx, viewing)
+}
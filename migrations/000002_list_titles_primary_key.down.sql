@@ -0,0 +1,5 @@
+ALTER TABLE list_titles DROP CONSTRAINT list_titles_pkey;
+
+ALTER TABLE list_titles ADD CONSTRAINT list_titles_title_id_key UNIQUE (title_id);
+
+ALTER TABLE list_titles ALTER COLUMN score DROP DEFAULT;
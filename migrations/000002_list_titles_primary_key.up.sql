@@ -0,0 +1,19 @@
+ALTER TABLE list_titles
+    ALTER COLUMN list_id DROP DEFAULT,
+    ALTER COLUMN list_id TYPE INTEGER,
+    ALTER COLUMN list_id SET NOT NULL,
+    ALTER COLUMN title_id DROP DEFAULT,
+    ALTER COLUMN title_id TYPE INTEGER,
+    ALTER COLUMN score DROP DEFAULT,
+    ALTER COLUMN score TYPE SMALLINT,
+    ALTER COLUMN score SET DEFAULT 0;
+
+DROP SEQUENCE IF EXISTS list_titles_list_id_seq;
+
+DROP SEQUENCE IF EXISTS list_titles_title_id_seq;
+
+DROP SEQUENCE IF EXISTS list_titles_score_seq;
+
+ALTER TABLE list_titles DROP CONSTRAINT list_titles_title_id_key;
+
+ALTER TABLE list_titles ADD PRIMARY KEY (list_id, title_id);
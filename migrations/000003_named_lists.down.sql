@@ -0,0 +1,11 @@
+DROP INDEX lists_owner_id_default_idx;
+
+ALTER TABLE lists DROP CONSTRAINT lists_owner_id_name_key;
+
+DELETE FROM lists WHERE NOT is_default;
+
+ALTER TABLE lists
+    DROP COLUMN name,
+    DROP COLUMN description,
+    DROP COLUMN is_default,
+    DROP COLUMN created_on;
@@ -0,0 +1,19 @@
+ALTER TABLE lists
+    ALTER COLUMN owner_id DROP DEFAULT,
+    ALTER COLUMN owner_id TYPE INTEGER,
+    ALTER COLUMN owner_id SET NOT NULL,
+    ADD COLUMN name VARCHAR(100) NOT NULL DEFAULT 'My list',
+    ADD COLUMN description VARCHAR(1000) NOT NULL DEFAULT '',
+    ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN created_on TIMESTAMP NOT NULL DEFAULT NOW();
+
+DROP SEQUENCE IF EXISTS lists_owner_id_seq;
+
+UPDATE lists SET is_default = TRUE
+WHERE id IN (SELECT MIN(id) FROM lists GROUP BY owner_id);
+
+ALTER TABLE lists ALTER COLUMN name DROP DEFAULT;
+
+ALTER TABLE lists ADD CONSTRAINT lists_owner_id_name_key UNIQUE (owner_id, name);
+
+CREATE UNIQUE INDEX lists_owner_id_default_idx ON lists (owner_id) WHERE is_default;
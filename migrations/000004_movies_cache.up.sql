@@ -0,0 +1,7 @@
+CREATE TABLE movies (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    updated_on TIMESTAMP NOT NULL
+);
+
+CREATE INDEX movies_updated_on_idx ON movies (updated_on);
@@ -0,0 +1,12 @@
+ALTER TABLE movies
+    DROP COLUMN alternative_name,
+    DROP COLUMN en_name,
+    DROP COLUMN type,
+    DROP COLUMN year,
+    DROP COLUMN genres,
+    DROP COLUMN countries,
+    DROP COLUMN duration,
+    DROP COLUMN age_rating,
+    DROP COLUMN poster_url,
+    DROP COLUMN kp_rating,
+    DROP COLUMN imdb_rating;
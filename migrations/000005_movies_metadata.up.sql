@@ -0,0 +1,15 @@
+ALTER TABLE movies
+    ADD COLUMN alternative_name VARCHAR(255),
+    ADD COLUMN en_name VARCHAR(255),
+    ADD COLUMN type VARCHAR(30),
+    ADD COLUMN year SMALLINT,
+    ADD COLUMN genres TEXT[],
+    ADD COLUMN countries TEXT[],
+    ADD COLUMN duration SMALLINT,
+    ADD COLUMN age_rating SMALLINT,
+    ADD COLUMN poster_url VARCHAR(255),
+    ADD COLUMN kp_rating REAL,
+    ADD COLUMN imdb_rating REAL;
+
+/* let the background refresh fetch metadata of the already cached movies */
+UPDATE movies SET updated_on = TIMESTAMP 'epoch';
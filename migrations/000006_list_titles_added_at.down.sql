@@ -0,0 +1,5 @@
+DROP INDEX list_titles_list_id_score_idx;
+
+DROP INDEX list_titles_list_id_added_at_idx;
+
+ALTER TABLE list_titles DROP COLUMN added_at;
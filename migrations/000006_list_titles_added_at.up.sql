@@ -0,0 +1,5 @@
+ALTER TABLE list_titles ADD COLUMN added_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+CREATE INDEX list_titles_list_id_added_at_idx ON list_titles (list_id, added_at);
+
+CREATE INDEX list_titles_list_id_score_idx ON list_titles (list_id, is_favorite, score);
@@ -0,0 +1,13 @@
+CREATE TABLE viewings (
+    id SERIAL PRIMARY KEY,
+    list_id INTEGER NOT NULL,
+    title_id INTEGER NOT NULL,
+    watched_on DATE NOT NULL,
+    score SMALLINT CHECK (score BETWEEN 0 AND 10),
+    is_rewatch BOOLEAN NOT NULL,
+    note VARCHAR(2000) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (list_id, title_id) REFERENCES list_titles (list_id, title_id) ON DELETE CASCADE
+);
+
+CREATE INDEX viewings_list_id_title_id_idx ON viewings (list_id, title_id, watched_on);
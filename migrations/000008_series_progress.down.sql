@@ -0,0 +1,7 @@
+ALTER TABLE list_titles
+    DROP COLUMN season,
+    DROP COLUMN episode;
+
+ALTER TABLE movies
+    DROP COLUMN is_series,
+    DROP COLUMN episodes_per_season;
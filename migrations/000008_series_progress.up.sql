@@ -0,0 +1,10 @@
+ALTER TABLE movies
+    ADD COLUMN is_series BOOLEAN,
+    ADD COLUMN episodes_per_season SMALLINT[];
+
+/* let the background refresh fetch seasons of the already cached series */
+UPDATE movies SET updated_on = TIMESTAMP 'epoch';
+
+ALTER TABLE list_titles
+    ADD COLUMN season SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN episode SMALLINT NOT NULL DEFAULT 0;
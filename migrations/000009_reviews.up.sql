@@ -0,0 +1,13 @@
+CREATE TABLE reviews (
+    list_id INTEGER NOT NULL,
+    title_id INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    has_spoilers BOOLEAN NOT NULL DEFAULT FALSE,
+    is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    edited_at TIMESTAMP,
+    PRIMARY KEY (list_id, title_id),
+    FOREIGN KEY (list_id, title_id) REFERENCES list_titles (list_id, title_id) ON DELETE CASCADE
+);
+
+CREATE INDEX reviews_public_title_id_idx ON reviews (title_id) WHERE is_public;
@@ -0,0 +1,3 @@
+DROP TABLE list_title_tags;
+
+DROP TABLE tags;
@@ -0,0 +1,17 @@
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    created_on TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (owner_id, name)
+);
+
+CREATE TABLE list_title_tags (
+    list_id INTEGER NOT NULL,
+    title_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (list_id, title_id, tag_id),
+    FOREIGN KEY (list_id, title_id) REFERENCES list_titles (list_id, title_id) ON DELETE CASCADE
+);
+
+CREATE INDEX list_title_tags_tag_id_idx ON list_title_tags (tag_id);
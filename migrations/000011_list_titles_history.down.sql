@@ -0,0 +1,13 @@
+DROP TRIGGER list_titles_track_history ON list_titles;
+
+DROP FUNCTION list_titles_track_history();
+
+DROP TRIGGER list_titles_set_timestamps ON list_titles;
+
+DROP FUNCTION list_titles_set_timestamps();
+
+DROP TABLE list_titles_history;
+
+ALTER TABLE list_titles
+    DROP COLUMN updated_at,
+    DROP COLUMN completed_at;
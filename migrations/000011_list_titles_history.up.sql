@@ -0,0 +1,67 @@
+ALTER TABLE list_titles
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ADD COLUMN completed_at TIMESTAMP;
+
+UPDATE list_titles SET updated_at = added_at;
+
+/* the last viewing from the diary is the best guess of when the movie was completed */
+UPDATE list_titles
+SET completed_at = (
+    SELECT MAX(viewings.watched_on) FROM viewings
+    WHERE viewings.list_id = list_titles.list_id AND viewings.title_id = list_titles.title_id
+)
+WHERE status_name = 'completed';
+
+CREATE TABLE list_titles_history (
+    id SERIAL PRIMARY KEY,
+    list_id INTEGER NOT NULL,
+    title_id INTEGER NOT NULL,
+    old_status_name VARCHAR(15),
+    new_status_name VARCHAR(15) NOT NULL,
+    old_score SMALLINT,
+    new_score SMALLINT NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (list_id, title_id) REFERENCES list_titles (list_id, title_id) ON DELETE CASCADE
+);
+
+CREATE INDEX list_titles_history_list_id_title_id_idx ON list_titles_history (list_id, title_id, changed_at);
+
+INSERT INTO list_titles_history (list_id, title_id, new_status_name, new_score, changed_at)
+SELECT list_id, title_id, status_name, score, added_at FROM list_titles;
+
+/*
+Timestamps and history are maintained by triggers, so every way of changing
+the entry (PATCH, diary, series progress) is tracked the same way
+*/
+CREATE FUNCTION list_titles_set_timestamps() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at := NOW();
+    IF NEW.status_name <> 'completed' THEN
+        NEW.completed_at := NULL;
+    ELSIF TG_OP = 'INSERT' OR OLD.status_name <> 'completed' THEN
+        NEW.completed_at := NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER list_titles_set_timestamps
+BEFORE INSERT OR UPDATE ON list_titles
+FOR EACH ROW EXECUTE FUNCTION list_titles_set_timestamps();
+
+CREATE FUNCTION list_titles_track_history() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO list_titles_history (list_id, title_id, new_status_name, new_score)
+        VALUES (NEW.list_id, NEW.title_id, NEW.status_name, NEW.score);
+    ELSIF NEW.status_name <> OLD.status_name OR NEW.score <> OLD.score THEN
+        INSERT INTO list_titles_history (list_id, title_id, old_status_name, new_status_name, old_score, new_score)
+        VALUES (NEW.list_id, NEW.title_id, OLD.status_name, NEW.status_name, OLD.score, NEW.score);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER list_titles_track_history
+AFTER INSERT OR UPDATE ON list_titles
+FOR EACH ROW EXECUTE FUNCTION list_titles_track_history();
@@ -0,0 +1,5 @@
+DROP TRIGGER list_titles_bump_version ON list_titles;
+
+DROP FUNCTION list_titles_bump_version();
+
+ALTER TABLE list_titles DROP COLUMN version;
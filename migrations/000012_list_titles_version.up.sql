@@ -0,0 +1,13 @@
+ALTER TABLE list_titles ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+/* any change of the entry makes the version held by clients outdated */
+CREATE FUNCTION list_titles_bump_version() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER list_titles_bump_version
+BEFORE UPDATE ON list_titles
+FOR EACH ROW EXECUTE FUNCTION list_titles_bump_version();
@@ -0,0 +1,5 @@
+DELETE FROM list_titles WHERE deleted_at IS NOT NULL;
+
+DROP INDEX list_titles_deleted_at_idx;
+
+ALTER TABLE list_titles DROP COLUMN deleted_at;
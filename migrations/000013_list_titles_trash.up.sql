@@ -0,0 +1,3 @@
+ALTER TABLE list_titles ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX list_titles_deleted_at_idx ON list_titles (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE lists DROP COLUMN visibility;
+
+ALTER TABLE users DROP COLUMN visibility;
@@ -0,0 +1,13 @@
+ALTER TABLE users
+    ADD COLUMN visibility VARCHAR(10) NOT NULL DEFAULT 'private'
+    CHECK (visibility IN ('private', 'unlisted', 'public'));
+
+/*
+The existing users have written public reviews already, so they stay visible:
+unlike the new users, they get unlisted profiles that are available by the link
+*/
+UPDATE users SET visibility = 'unlisted';
+
+ALTER TABLE lists
+    ADD COLUMN visibility VARCHAR(10) NOT NULL DEFAULT 'private'
+    CHECK (visibility IN ('private', 'unlisted', 'public'));
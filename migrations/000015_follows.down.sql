@@ -0,0 +1,3 @@
+DROP TABLE activities;
+
+DROP TABLE follows;
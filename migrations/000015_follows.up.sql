@@ -0,0 +1,22 @@
+CREATE TABLE follows (
+    follower_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    followee_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_on TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX follows_followee_id_idx ON follows (followee_id);
+
+CREATE TABLE activities (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    list_id INTEGER NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    title_id INTEGER NOT NULL,
+    kind VARCHAR(20) NOT NULL
+        CHECK (kind IN ('added', 'completed', 'scored', 'favorited', 'reviewed')),
+    score SMALLINT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX activities_user_id_created_at_idx ON activities (user_id, created_at DESC, id DESC);
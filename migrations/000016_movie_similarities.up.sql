@@ -0,0 +1,7 @@
+CREATE TABLE movie_similarities (
+    title_id INTEGER NOT NULL,
+    similar_id INTEGER NOT NULL,
+    similarity REAL NOT NULL,
+    common_users INTEGER NOT NULL,
+    PRIMARY KEY (title_id, similar_id)
+);
@@ -0,0 +1 @@
+ALTER TABLE movies DROP COLUMN imdb_id;
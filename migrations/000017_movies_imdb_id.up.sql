@@ -0,0 +1,3 @@
+ALTER TABLE movies ADD COLUMN imdb_id VARCHAR(12);
+
+CREATE INDEX movies_imdb_id_idx ON movies (imdb_id);
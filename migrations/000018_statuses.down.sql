@@ -0,0 +1,17 @@
+DROP TABLE statuses;
+
+/* custom statuses don't exist without the table */
+UPDATE list_titles SET status_name = 'on-hold'
+WHERE status_name NOT IN ('watching', 'completed', 'on-hold', 'dropped', 'plan to watch');
+
+UPDATE list_titles_history SET old_status_name = 'on-hold'
+WHERE old_status_name NOT IN ('watching', 'completed', 'on-hold', 'dropped', 'plan to watch');
+
+UPDATE list_titles_history SET new_status_name = 'on-hold'
+WHERE new_status_name NOT IN ('watching', 'completed', 'on-hold', 'dropped', 'plan to watch');
+
+ALTER TABLE list_titles_history
+    ALTER COLUMN old_status_name TYPE VARCHAR(15),
+    ALTER COLUMN new_status_name TYPE VARCHAR(15);
+
+ALTER TABLE list_titles ALTER COLUMN status_name TYPE VARCHAR(15);
@@ -0,0 +1,20 @@
+ALTER TABLE list_titles ALTER COLUMN status_name TYPE VARCHAR(30);
+
+ALTER TABLE list_titles_history
+    ALTER COLUMN old_status_name TYPE VARCHAR(30),
+    ALTER COLUMN new_status_name TYPE VARCHAR(30);
+
+CREATE TABLE statuses (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name VARCHAR(30) NOT NULL,
+    position INTEGER NOT NULL,
+    is_builtin BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (owner_id, name)
+);
+
+INSERT INTO statuses (owner_id, name, position, is_builtin)
+SELECT users.id, defaults.name, defaults.position, TRUE
+FROM users CROSS JOIN (
+    VALUES ('watching', 1), ('completed', 2), ('on-hold', 3), ('dropped', 4), ('plan to watch', 5)
+) AS defaults (name, position);
@@ -0,0 +1,3 @@
+DROP INDEX list_titles_list_id_rank_idx;
+
+ALTER TABLE list_titles DROP COLUMN rank;
@@ -0,0 +1,27 @@
+/*
+Manual order of the list entries. Ranks are keys of fractional indexing compared
+byte by byte: a moved entry gets a key between the keys of its new neighbours,
+so the other entries keep theirs. Keys consist of the digits 0-9A-Za-z and never end
+with "0", otherwise there would be no key right before them
+*/
+ALTER TABLE list_titles ADD COLUMN rank TEXT COLLATE "C";
+
+/* ranking isn't a change of the entry, so it neither bumps the version nor the timestamps */
+ALTER TABLE list_titles DISABLE TRIGGER list_titles_set_timestamps;
+ALTER TABLE list_titles DISABLE TRIGGER list_titles_bump_version;
+
+UPDATE list_titles SET rank = ranked.rank
+FROM (
+    SELECT list_id, title_id, lpad(ROW_NUMBER() OVER (
+        PARTITION BY list_id ORDER BY is_favorite DESC, score DESC, title_id
+    )::text, 8, '0') || 'V' AS rank
+    FROM list_titles
+) AS ranked
+WHERE list_titles.list_id = ranked.list_id AND list_titles.title_id = ranked.title_id;
+
+ALTER TABLE list_titles ENABLE TRIGGER list_titles_set_timestamps;
+ALTER TABLE list_titles ENABLE TRIGGER list_titles_bump_version;
+
+ALTER TABLE list_titles ALTER COLUMN rank SET NOT NULL;
+
+CREATE INDEX list_titles_list_id_rank_idx ON list_titles (list_id, rank);
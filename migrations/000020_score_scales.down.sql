@@ -0,0 +1,22 @@
+/* scores from 1 to 4 are kept as 1 rather than turned into no score */
+UPDATE activities SET score = GREATEST(ROUND(score / 10.0), SIGN(score));
+
+ALTER TABLE viewings DROP CONSTRAINT viewings_score_check;
+
+UPDATE viewings SET score = GREATEST(ROUND(score / 10.0), SIGN(score));
+
+ALTER TABLE viewings ADD CONSTRAINT viewings_score_check CHECK (score BETWEEN 0 AND 10);
+
+UPDATE list_titles_history SET old_score = GREATEST(ROUND(old_score / 10.0), SIGN(old_score)), new_score = GREATEST(ROUND(new_score / 10.0), SIGN(new_score));
+
+ALTER TABLE list_titles DROP CONSTRAINT list_titles_score_check;
+
+ALTER TABLE list_titles DISABLE TRIGGER USER;
+
+UPDATE list_titles SET score = GREATEST(ROUND(score / 10.0), SIGN(score));
+
+ALTER TABLE list_titles ENABLE TRIGGER USER;
+
+ALTER TABLE list_titles ADD CONSTRAINT list_titles_score_check CHECK (score BETWEEN 0 AND 10);
+
+ALTER TABLE users DROP COLUMN score_scale;
@@ -0,0 +1,27 @@
+/*
+Scores are kept on the 0-100 scale (0 is still no score),
+users enter and see them on the scale of their choice
+*/
+ALTER TABLE users ADD COLUMN score_scale VARCHAR(15) NOT NULL DEFAULT 'ten'
+    CHECK (score_scale IN ('ten', 'five_stars', 'hundred'));
+
+ALTER TABLE list_titles DROP CONSTRAINT list_titles_score_check;
+
+/* the scale isn't a change of the entry, so it's neither versioned nor tracked */
+ALTER TABLE list_titles DISABLE TRIGGER USER;
+
+UPDATE list_titles SET score = score * 10;
+
+ALTER TABLE list_titles ENABLE TRIGGER USER;
+
+ALTER TABLE list_titles ADD CONSTRAINT list_titles_score_check CHECK (score BETWEEN 0 AND 100);
+
+UPDATE list_titles_history SET old_score = old_score * 10, new_score = new_score * 10;
+
+ALTER TABLE viewings DROP CONSTRAINT viewings_score_check;
+
+UPDATE viewings SET score = score * 10;
+
+ALTER TABLE viewings ADD CONSTRAINT viewings_score_check CHECK (score BETWEEN 0 AND 100);
+
+UPDATE activities SET score = score * 10;
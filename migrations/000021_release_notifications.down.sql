@@ -0,0 +1,7 @@
+DROP TABLE notifications;
+
+ALTER TABLE users DROP COLUMN email_notifications;
+
+ALTER TABLE movies
+    DROP COLUMN premiere,
+    DROP COLUMN digital_release;
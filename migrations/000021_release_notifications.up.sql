@@ -0,0 +1,22 @@
+ALTER TABLE movies
+    ADD COLUMN premiere DATE,
+    ADD COLUMN digital_release DATE;
+
+ALTER TABLE users ADD COLUMN email_notifications BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    title_id INTEGER NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('premiere', 'digital_release')),
+    release_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    dismissed_at TIMESTAMP,
+    UNIQUE (user_id, title_id, kind)
+);
+
+CREATE INDEX notifications_user_id_created_at_idx ON notifications (user_id, created_at DESC)
+    WHERE dismissed_at IS NULL;
+
+/* let the background refresh fetch release dates of the already cached movies */
+UPDATE movies SET updated_on = TIMESTAMP 'epoch';
@@ -0,0 +1,13 @@
+/* sequels and prequels of the cached movies, the parts themselves may not be cached */
+CREATE TABLE movie_sequels (
+    movie_id INTEGER NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    sequel_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    en_name VARCHAR(255),
+    type VARCHAR(30),
+    poster_url VARCHAR(255),
+    PRIMARY KEY (movie_id, sequel_id)
+);
+
+/* let the background refresh fetch sequels and prequels of the already cached movies */
+UPDATE movies SET updated_on = TIMESTAMP 'epoch';
@@ -0,0 +1,18 @@
+CREATE OR REPLACE FUNCTION list_titles_set_timestamps() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at := NOW();
+    IF NEW.status_name <> 'completed' THEN
+        NEW.completed_at := NULL;
+    ELSIF TG_OP = 'INSERT' OR OLD.status_name <> 'completed' THEN
+        NEW.completed_at := NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION list_titles_bump_version() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
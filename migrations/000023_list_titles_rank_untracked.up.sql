@@ -0,0 +1,30 @@
+/*
+Ranking isn't a change of the entry, so the updates of the rank alone neither bump
+the version nor the timestamps, otherwise reordering would break If-Match of the clients
+*/
+CREATE OR REPLACE FUNCTION list_titles_set_timestamps() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.rank IS DISTINCT FROM OLD.rank AND to_jsonb(NEW) - 'rank' = to_jsonb(OLD) - 'rank' THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+    NEW.updated_at := NOW();
+    IF NEW.status_name <> 'completed' THEN
+        NEW.completed_at := NULL;
+    ELSIF TG_OP = 'INSERT' OR OLD.status_name <> 'completed' THEN
+        NEW.completed_at := NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION list_titles_bump_version() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.rank IS DISTINCT FROM OLD.rank AND to_jsonb(NEW) - 'rank' = to_jsonb(OLD) - 'rank' THEN
+        RETURN NEW;
+    END IF;
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1 @@
+ALTER TABLE movie_sequels DROP COLUMN year;
@@ -0,0 +1,5 @@
+/* the year of the part itself, so the parts that aren't cached are ordered in the collection too */
+ALTER TABLE movie_sequels ADD COLUMN year SMALLINT;
+
+/* let the background refresh fetch the years of the already cached sequels and prequels */
+UPDATE movies SET updated_on = TIMESTAMP 'epoch';
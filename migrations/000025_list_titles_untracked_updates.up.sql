@@ -0,0 +1,51 @@
+/*
+Updates made by the app on behalf of the user, like renaming a status, aren't changes of the
+entries: the transaction sets mykinolist.untracked locally, and the triggers skip its updates
+*/
+CREATE OR REPLACE FUNCTION list_titles_set_timestamps() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF current_setting('mykinolist.untracked', true) = 'on' THEN
+            RETURN NEW;
+        END IF;
+        IF NEW.rank IS DISTINCT FROM OLD.rank AND to_jsonb(NEW) - 'rank' = to_jsonb(OLD) - 'rank' THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+    NEW.updated_at := NOW();
+    IF NEW.status_name <> 'completed' THEN
+        NEW.completed_at := NULL;
+    ELSIF TG_OP = 'INSERT' OR OLD.status_name <> 'completed' THEN
+        NEW.completed_at := NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION list_titles_bump_version() RETURNS TRIGGER AS $$
+BEGIN
+    IF current_setting('mykinolist.untracked', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+    IF NEW.rank IS DISTINCT FROM OLD.rank AND to_jsonb(NEW) - 'rank' = to_jsonb(OLD) - 'rank' THEN
+        RETURN NEW;
+    END IF;
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION list_titles_track_history() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO list_titles_history (list_id, title_id, new_status_name, new_score)
+        VALUES (NEW.list_id, NEW.title_id, NEW.status_name, NEW.score);
+    ELSIF current_setting('mykinolist.untracked', true) = 'on' THEN
+        RETURN NULL;
+    ELSIF NEW.status_name <> OLD.status_name OR NEW.score <> OLD.score THEN
+        INSERT INTO list_titles_history (list_id, title_id, old_status_name, new_status_name, old_score, new_score)
+        VALUES (NEW.list_id, NEW.title_id, OLD.status_name, NEW.status_name, OLD.score, NEW.score);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
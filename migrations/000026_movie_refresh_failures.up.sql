@@ -0,0 +1,9 @@
+/*
+Movies that failed to be fetched for the cache, e.g. Kinopoisk doesn't return them anymore.
+They're retried after the others, so that they don't take the whole refresh batch every time
+*/
+CREATE TABLE movie_refresh_failures (
+    movie_id INTEGER PRIMARY KEY,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    failed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
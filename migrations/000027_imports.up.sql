@@ -0,0 +1,28 @@
+/* imported files are processed in the background, a batch of rows at a time */
+CREATE TABLE imports (
+    id SERIAL PRIMARY KEY,
+    list_id INTEGER NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    format VARCHAR(30) NOT NULL,
+    total INTEGER NOT NULL,
+    processed INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE TABLE import_rows (
+    import_id INTEGER NOT NULL REFERENCES imports (id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    year INTEGER NOT NULL,
+    imdb_id VARCHAR(15),
+    status_name VARCHAR(30) NOT NULL,
+    score SMALLINT NOT NULL,
+    error TEXT,
+    movie JSONB,
+    candidates JSONB,
+    processed_at TIMESTAMP,
+    PRIMARY KEY (import_id, line)
+);
+
+CREATE INDEX import_rows_pending_idx ON import_rows (import_id, line) WHERE processed_at IS NULL;